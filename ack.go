@@ -0,0 +1,116 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ErrNacked is wrapped by the error reported when a handler subscribed with
+// SubscribeAck nacked an event on every attempt to deliver it.
+var ErrNacked = errors.New("eventbus: event nacked")
+
+// Ack is the acknowledgement of an event returned by an AckHandler.
+type Ack int
+
+const (
+	// Acked acknowledges the event was handled.
+	Acked Ack = iota
+	// Nacked requests the event is redelivered later, such as because a resource
+	// the handler depends on is temporarily unavailable.
+	Nacked
+)
+
+// AckHandler is a handler of events of type T which acknowledges each event it
+// handles, or requests it is redelivered.
+type AckHandler[T any] interface {
+	OnEvent(event T) Ack
+}
+
+// AckHandlerFunc is a function that implements the AckHandler interface.
+type AckHandlerFunc[T any] func(event T) Ack
+
+func (f AckHandlerFunc[T]) OnEvent(event T) Ack {
+	return f(event)
+}
+
+// ackingHandler redelivers the events nacked by the underlying handler.
+type ackingHandler[T any] struct {
+	handler     AckHandler[T]
+	delay       time.Duration
+	maxAttempts int
+	done        chan struct{}
+}
+
+func (a *ackingHandler[T]) OnEvent(event T) error {
+	if a.handler.OnEvent(event) == Acked {
+		return nil
+	}
+	if a.maxAttempts == 1 {
+		return a.nacked()
+	}
+	go a.redeliver(event)
+	return nil
+}
+
+// redeliver delivers the event to the handler again after the delay, doubling
+// the delay after each attempt, until the handler acks it or it has been
+// delivered maxAttempts times.
+func (a *ackingHandler[T]) redeliver(event T) {
+	delay := a.delay
+	for attempt := 2; attempt <= a.maxAttempts; attempt++ {
+		select {
+		case <-currentClock().After(delay):
+		case <-a.done:
+			return
+		}
+		if a.handler.OnEvent(event) == Acked {
+			return
+		}
+		delay *= 2
+	}
+
+	mu.RLock()
+	callback := cfg.errorCallback
+	mu.RUnlock()
+	if callback != nil {
+		callback(a.nacked())
+	}
+}
+
+func (a *ackingHandler[T]) nacked() error {
+	return fmt.Errorf("%w: %s after %d attempts", ErrNacked, ShortTypeName(TypeOf[T]()), a.maxAttempts)
+}
+
+func (a *ackingHandler[T]) stop() {
+	close(a.done)
+}
+
+// SubscribeAck registers a handler for a given type which acknowledges each
+// event, with at least once delivery for events it nacks. An event the handler
+// nacks is redelivered to it, and only it, on another goroutine after the delay,
+// which doubles after each redelivery so a handler waiting on a resource backs
+// off, until the handler acks the event or it has been delivered maxAttempts
+// times. An event nacked on every attempt is reported to the error callback with
+// an error wrapping ErrNacked, or returned from the publish when maxAttempts is
+// one. Redeliveries still pending when the handler is unsubscribed are dropped.
+// SubscribeAck panics if maxAttempts is less than one. The return value is a
+// subscription ID that can be used to unsubscribe the handler.
+func SubscribeAck[T any](handler AckHandler[T], delay time.Duration, maxAttempts int) uint64 {
+	mustNotBeNil(handler)
+	if maxAttempts < 1 {
+		panic("eventbus: SubscribeAck requires at least one attempt")
+	}
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	a := &ackingHandler[T]{
+		handler:     handler,
+		delay:       delay,
+		maxAttempts: maxAttempts,
+		done:        make(chan struct{}),
+	}
+	return subscribe(reflect.TypeOf(*new(T)), a, errorHandlerInvoker[T](a), 2)
+}
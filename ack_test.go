@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeAck(t *testing.T) {
+	reset()
+	var reported atomic.Int32
+	Configure(WithErrorCallback(func(error) {
+		reported.Add(1)
+	}))
+
+	var attempts atomic.Int32
+	acked := make(chan progressEvent, 1)
+	SubscribeAck[progressEvent](AckHandlerFunc[progressEvent](func(event progressEvent) Ack {
+		if attempts.Add(1) < 3 {
+			return Nacked
+		}
+		acked <- event
+		return Acked
+	}), time.Millisecond, 5)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	select {
+	case event := <-acked:
+		assert.Equal(t, progressEvent{Count: 1}, event)
+	case <-time.After(time.Second):
+		t.Fatal("nacked event was not redelivered")
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Zero(t, reported.Load())
+}
+
+func TestSubscribeAck_MaxAttempts(t *testing.T) {
+	reset()
+	reported := make(chan error, 1)
+	Configure(WithErrorCallback(func(err error) {
+		reported <- err
+	}))
+
+	var attempts atomic.Int32
+	SubscribeAck[progressEvent](AckHandlerFunc[progressEvent](func(progressEvent) Ack {
+		attempts.Add(1)
+		return Nacked
+	}), time.Millisecond, 3)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	select {
+	case err := <-reported:
+		assert.ErrorIs(t, err, ErrNacked)
+	case <-time.After(time.Second):
+		t.Fatal("exhausted redeliveries were not reported")
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// Without redeliveries the publish returns the nack
+	reset()
+	SubscribeAck[orderEvent](AckHandlerFunc[orderEvent](func(orderEvent) Ack {
+		return Nacked
+	}), time.Millisecond, 1)
+	assert.ErrorIs(t, Publish(orderEvent{Seq: 1}), ErrNacked)
+}
+
+func TestSubscribeAck_Unsubscribe(t *testing.T) {
+	reset()
+	var attempts atomic.Int32
+	id := SubscribeAck[progressEvent](AckHandlerFunc[progressEvent](func(progressEvent) Ack {
+		attempts.Add(1)
+		return Nacked
+	}), 20*time.Millisecond, 3)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.True(t, Unsubscribe[progressEvent](id))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), attempts.Load())
+}
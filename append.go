@@ -0,0 +1,29 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AppendHook is invoked with every event published before it is delivered to any
+// handler, see WithAppendHook.
+type AppendHook func(eventType reflect.Type, event any) error
+
+// appendEvent invokes the append hook with the event unless it is being
+// replayed, returning the error the publish is aborted with if the hook fails.
+// The hook is invoked with the read lock released. The caller must hold the read
+// lock.
+func appendEvent(eventType reflect.Type, event any, d delivery) error {
+	hook := cfg.appendHook
+	if hook == nil || d.replayed {
+		return nil
+	}
+	var err error
+	unlocked(func() {
+		err = hook(eventType, event)
+	})
+	if err != nil {
+		return fmt.Errorf("eventbus: appending event %s: %w", typeName(eventType), err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAppendHook(t *testing.T) {
+	reset()
+	var store []any
+	Configure(WithAppendHook(func(eventType reflect.Type, event any) error {
+		assert.Equal(t, TypeOf[userCreatedEvent](), eventType)
+		store = append(store, event)
+		return nil
+	}))
+
+	var handled []any
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		// The event is appended before it is handled
+		assert.Len(t, store, len(handled)+1)
+		handled = append(handled, event)
+	}))
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.NoError(t, PublishBalanced(userCreatedEvent{Name: "Jane Doe"}))
+	assert.Equal(t, []any{userCreatedEvent{Name: "John Doe"}, userCreatedEvent{Name: "Jane Doe"}}, store)
+	assert.Equal(t, store, handled)
+}
+
+func TestWithAppendHook_Error(t *testing.T) {
+	reset()
+	errStore := errors.New("store unavailable")
+	Configure(WithAppendHook(func(eventType reflect.Type, event any) error {
+		return errStore
+	}))
+
+	invoked := false
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		invoked = true
+	}))
+
+	assert.ErrorIs(t, Publish(userCreatedEvent{Name: "John Doe"}), errStore)
+	assert.ErrorIs(t, PublishAsync(userCreatedEvent{Name: "John Doe"}), errStore)
+	assert.ErrorIs(t, PublishBalanced(userCreatedEvent{Name: "John Doe"}), errStore)
+	assert.False(t, invoked)
+}
+
+func TestWithAppendHook_SubscribeFromHook(t *testing.T) {
+	reset()
+	Configure(WithAppendHook(func(eventType reflect.Type, event any) error {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+		return nil
+	}))
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {}))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- Publish(userCreatedEvent{Name: "John Doe"})
+	}()
+	select {
+	case err := <-published:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked subscribing from the append hook")
+	}
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+}
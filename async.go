@@ -0,0 +1,73 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrExpired is reported through the error callback when an event published with
+// PublishAsyncTTL is dropped because its TTL elapsed before it was delivered.
+var ErrExpired = errors.New("eventbus: event expired before delivery")
+
+var expiredCount uint64 = 0
+
+// expired reports whether an asynchronous handler invocation should be skipped
+// because the context is done or the event has expired. Expired events are
+// counted and reported to the error callback.
+func (d delivery) expired(name string, callback func(err error)) bool {
+	if d.ctx != nil && d.ctx.Err() != nil {
+		return true
+	}
+	if !d.expiresAt.IsZero() && now().After(d.expiresAt) {
+		atomic.AddUint64(&expiredCount, 1)
+		if callback != nil {
+			callback(fmt.Errorf("%w: %s", ErrExpired, name))
+		}
+		return true
+	}
+	return false
+}
+
+// Cloneable is implemented by events that can produce an independent copy of
+// themselves. When deep copying is enabled with WithDeepCopyAsync, events that
+// implement Cloneable are cloned for each handler invoked asynchronously so
+// handlers can't observe each other's mutations of shared slices or maps.
+type Cloneable[T any] interface {
+	Clone() T
+}
+
+// copyAsync returns the event to deliver to an asynchronously invoked handler,
+// which is a clone of the event if deep copying is enabled and the event
+// implements Cloneable, otherwise the event itself. The caller must hold the
+// read lock.
+func copyAsync[T any](event T) T {
+	if !cfg.deepCopyAsync {
+		return event
+	}
+	if c, ok := any(event).(Cloneable[T]); ok {
+		return c.Clone()
+	}
+	return event
+}
+
+// PublishAsyncTTL behaves like PublishAsync but the event is only delivered to a
+// handler if the handler is invoked within ttl of the event being published. If
+// the TTL has elapsed, such as when the event was buffered while its type was
+// paused, the event is dropped for that handler instead of being delivered. Each
+// dropped delivery is counted, see ExpiredCount, and reported to the error
+// callback with an error wrapping ErrExpired.
+func PublishAsyncTTL[T any](event T, ttl time.Duration) error {
+	return publishAsync(event, delivery{
+		ctx:       context.Background(),
+		expiresAt: now().Add(ttl),
+	})
+}
+
+// ExpiredCount returns the total number of deliveries dropped because the TTL of
+// an event published with PublishAsyncTTL elapsed before it was delivered.
+func ExpiredCount() uint64 {
+	return atomic.LoadUint64(&expiredCount)
+}
@@ -0,0 +1,45 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type taggedEvent struct {
+	Tags []string
+}
+
+func (e taggedEvent) Clone() taggedEvent {
+	tags := make([]string, len(e.Tags))
+	copy(tags, e.Tags)
+	return taggedEvent{Tags: tags}
+}
+
+func TestPublishAsync_DeepCopy(t *testing.T) {
+	reset()
+	Configure(WithDeepCopyAsync())
+
+	mutated := make(chan struct{})
+	observed := make(chan string, 1)
+	Subscribe[taggedEvent](HandlerFunc[taggedEvent](func(event taggedEvent) {
+		event.Tags[0] = "mutated"
+		close(mutated)
+	}))
+	Subscribe[taggedEvent](HandlerFunc[taggedEvent](func(event taggedEvent) {
+		<-mutated
+		observed <- event.Tags[0]
+	}))
+
+	event := taggedEvent{Tags: []string{"original"}}
+	assert.NoError(t, PublishAsync(event))
+
+	select {
+	case tag := <-observed:
+		assert.Equal(t, "original", tag)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for handler")
+	}
+	assert.Equal(t, "original", event.Tags[0])
+}
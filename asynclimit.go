@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"errors"
+)
+
+// ErrAsyncLimit is returned by PublishAsync when a handler was not invoked
+// because the cap set with WithMaxAsyncGoroutines was reached and the policy is
+// RejectAsync.
+var ErrAsyncLimit = errors.New("eventbus: async goroutine limit reached")
+
+// AsyncLimitPolicy determines what PublishAsync does with a handler when the cap
+// on async goroutines set with WithMaxAsyncGoroutines has been reached.
+type AsyncLimitPolicy int
+
+const (
+	// RunSynchronously invokes the handler on the publishing goroutine.
+	RunSynchronously AsyncLimitPolicy = iota
+	// RejectAsync skips the handler and reports ErrAsyncLimit.
+	RejectAsync
+)
+
+// ActiveAsyncGoroutines returns the number of goroutines currently running
+// handlers invoked by PublishAsync.
+func ActiveAsyncGoroutines() int {
+	return inflight.count()
+}
+
+// goAsync runs fn on a new goroutine, or submits it to the executor set with
+// WithSubmitFunc, unless the cap on async goroutines has been reached, in which
+// case fn is run synchronously or ErrAsyncLimit is returned depending on the
+// configured policy. The caller must hold the read lock.
+func goAsync(fn func()) error {
+	counter := inflight
+	if !counter.tryAdd(cfg.maxAsyncGoroutines) {
+		if cfg.asyncLimitPolicy == RejectAsync {
+			return ErrAsyncLimit
+		}
+		unlocked(fn)
+		return nil
+	}
+
+	task := func() {
+		defer counter.done()
+		fn()
+	}
+	if submit := cfg.submitFunc; submit != nil {
+		// The executor may run the task on the submitting goroutine.
+		unlocked(func() {
+			submit(task)
+		})
+		return nil
+	}
+	go task()
+	return nil
+}
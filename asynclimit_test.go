@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMaxAsyncGoroutines(t *testing.T) {
+	reset()
+	Configure(WithMaxAsyncGoroutines(2, RunSynchronously))
+
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+			<-release
+		}))
+	}
+	var invoked atomic.Int32
+	for i := 0; i < 2; i++ {
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+			invoked.Add(1)
+		}))
+	}
+
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	assert.Equal(t, 2, ActiveAsyncGoroutines())
+
+	// The cap has been reached so the handlers run before PublishAsync returns
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.Equal(t, int32(2), invoked.Load())
+	assert.Equal(t, 2, ActiveAsyncGoroutines())
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithMaxAsyncGoroutines_Reject(t *testing.T) {
+	reset()
+	Configure(WithMaxAsyncGoroutines(2, RejectAsync))
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	for i := 0; i < 3; i++ {
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+			defer wg.Done()
+			<-release
+		}))
+	}
+
+	err := PublishAsync(progressEvent{Count: 1})
+	assert.ErrorIs(t, err, ErrAsyncLimit)
+	assert.Equal(t, 2, ActiveAsyncGoroutines())
+
+	close(release)
+	wg.Wait()
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithSubmitFunc(t *testing.T) {
+	reset()
+
+	// Serialize every task on a single executor goroutine
+	tasks := make(chan func(), 16)
+	executor := make(chan uint64, 1)
+	go func() {
+		executor <- goroutineID()
+		for task := range tasks {
+			task()
+		}
+	}()
+	defer close(tasks)
+	var submitted atomic.Int32
+	Configure(WithSubmitFunc(func(task func()) {
+		submitted.Add(1)
+		tasks <- task
+	}))
+
+	var mu sync.Mutex
+	var received []int
+	goroutines := make(map[uint64]bool)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.Count)
+		goroutines[goroutineID()] = true
+	}))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(5), submitted.Load())
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, received)
+	assert.Equal(t, map[uint64]bool{<-executor: true}, goroutines)
+}
@@ -0,0 +1,105 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// AuditEntry is an event recorded in the audit log enabled with WithAuditLog.
+type AuditEntry struct {
+	// Type is the type of the event.
+	Type reflect.Type
+	// TypeName is the name of the type of the event given by the TypeNamer
+	// configured with WithTypeNamer.
+	TypeName string
+	// Event is the event that was published.
+	Event any
+	// Time is when the event was published.
+	Time time.Time
+	// ID identifies the event when causal tracing is enabled with
+	// WithCausalTracing, otherwise it is zero.
+	ID uint64
+	// CauseID is the ID of the event whose handler published the event when
+	// causal tracing is enabled, or zero if it wasn't published by a handler.
+	CauseID uint64
+}
+
+// auditLog holds the events recorded while the audit log is enabled.
+var auditLog = auditBuffer{}
+
+// auditBuffer is a ring buffer of the most recent audit entries.
+type auditBuffer struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+	next    int
+}
+
+func (b *auditBuffer) record(entry AuditEntry, size int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) < size {
+		b.entries = append(b.entries, entry)
+		return
+	}
+	// The log may have been shrunk since it filled up, discard the oldest entries
+	// so it holds at most size entries.
+	if len(b.entries) > size {
+		b.entries = append(b.entries[b.next:], b.entries[:b.next]...)[len(b.entries)-size:]
+		b.next = 0
+	}
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % size
+}
+
+func (b *auditBuffer) snapshot() []AuditEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries := make([]AuditEntry, 0, len(b.entries))
+	entries = append(entries, b.entries[b.next:]...)
+	return append(entries, b.entries[:b.next]...)
+}
+
+// recordAudit records the event in the audit log if it is enabled and the event
+// isn't being replayed. The caller must hold the read lock.
+func recordAudit[T any](eventType reflect.Type, event T, d delivery) {
+	if cfg.auditLogSize <= 0 || d.replayed {
+		return
+	}
+	auditLog.record(AuditEntry{
+		Type:     eventType,
+		TypeName: typeName(eventType),
+		Event:    event,
+		Time:     now(),
+		ID:       d.eventID,
+		CauseID:  d.causeID,
+	}, cfg.auditLogSize)
+}
+
+// AuditLog returns the events recorded in the audit log enabled with
+// WithAuditLog, oldest first.
+func AuditLog() []AuditEntry {
+	return auditLog.snapshot()
+}
+
+// Replay publishes each of the entries, in order, to the handlers currently
+// registered for their types, such as to reprocess events or reproduce a bug
+// using entries returned by AuditLog. Replayed events are delivered the same as
+// events published with Publish, except they are not recorded in the audit log
+// again. Replay continues after an event fails to publish and returns the errors
+// of every event that failed joined together.
+func Replay(entries []AuditEntry) error {
+	var errs []error
+	for _, entry := range entries {
+		if entry.Event == nil {
+			continue
+		}
+		if err := publish(entry.Event, delivery{replayed: true}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	reset()
+	Configure(WithAuditLog(10))
+
+	progressID := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	userID := Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {}))
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+
+	entries := AuditLog()
+	assert.Len(t, entries, 3)
+	assert.Equal(t, reflect.TypeOf(userCreatedEvent{}), entries[1].Type)
+	assert.Equal(t, userCreatedEvent{Name: "John Doe"}, entries[1].Event)
+
+	assert.True(t, Unsubscribe[progressEvent](progressID))
+	assert.True(t, Unsubscribe[userCreatedEvent](userID))
+
+	var received []any
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event)
+	}))
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		received = append(received, event)
+	}))
+
+	assert.NoError(t, Replay(entries))
+	assert.Equal(t, []any{progressEvent{Count: 1}, userCreatedEvent{Name: "John Doe"}, progressEvent{Count: 2}}, received)
+
+	// Replayed events aren't recorded again
+	assert.Len(t, AuditLog(), 3)
+}
+
+func TestWithAuditLog_Size(t *testing.T) {
+	reset()
+	Configure(WithAuditLog(2))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	entries := AuditLog()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, progressEvent{Count: 4}, entries[0].Event)
+	assert.Equal(t, progressEvent{Count: 5}, entries[1].Event)
+}
+
+func TestAuditLog_Disabled(t *testing.T) {
+	reset()
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.Empty(t, AuditLog())
+}
@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// ErrHandlerRemoved is wrapped by the error reported to the error callback when a
+// handler is unsubscribed because it panicked repeatedly, see
+// WithAutoRemoveOnPanic.
+var ErrHandlerRemoved = errors.New("eventbus: handler removed after repeated panics")
+
+// panicTracker counts the consecutive panics of a handler when
+// WithAutoRemoveOnPanic is enabled.
+type panicTracker struct {
+	threshold   int32
+	consecutive atomic.Int32
+}
+
+// removed reports whether the handler has panicked threshold times in a row and
+// is being unsubscribed, so it must no longer be invoked.
+func (p *panicTracker) removed() bool {
+	return p != nil && p.consecutive.Load() >= p.threshold
+}
+
+// observe must be deferred before recoverPanic. It counts the invocation of the
+// handler with the subscription ID as a panic if err wraps ErrHandlerPanic,
+// otherwise it resets the count, and unsubscribes the handler once it has
+// panicked threshold times in a row.
+func (p *panicTracker) observe(eventType reflect.Type, id uint64, callback func(err error), err *error) {
+	if !errors.Is(*err, ErrHandlerPanic) {
+		p.consecutive.Store(0)
+		return
+	}
+	if p.consecutive.Add(1) == p.threshold {
+		// The read lock may be held by Publish, so the handler is unsubscribed on
+		// another goroutine. It is skipped until then.
+		go removePanicking(eventType, id, callback, p.threshold)
+	}
+}
+
+// observing wraps invoke so the panics recovered by recovering are counted by
+// observe.
+func (p *panicTracker) observing(eventType reflect.Type, id uint64, callback func(err error), invoke func(event any) error) func(event any) error {
+	return func(event any) (err error) {
+		defer p.observe(eventType, id, callback, &err)
+		return invoke(event)
+	}
+}
+
+// removePanicking unsubscribes the handler with the subscription ID and reports
+// its removal to the error callback.
+func removePanicking(eventType reflect.Type, id uint64, callback func(err error), panics int32) {
+	mu.Lock()
+	removed := removeWhere(eventType, func(entry handlerEntry) bool {
+		return entry.id == id
+	})
+	name := typeName(eventType)
+	unlockAndNotify()
+
+	if removed > 0 && callback != nil {
+		callback(fmt.Errorf("%w: subscription %d for event %s panicked %d times in a row", ErrHandlerRemoved, id, name, panics))
+	}
+}
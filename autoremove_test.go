@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAutoRemoveOnPanic(t *testing.T) {
+	reset()
+	var recordMu sync.Mutex
+	var reported []error
+	Configure(WithAutoRemoveOnPanic(3), WithLifecycleEvents(), WithErrorCallback(func(err error) {
+		recordMu.Lock()
+		defer recordMu.Unlock()
+		reported = append(reported, err)
+	}))
+	var removed []SubscriptionRemoved
+	Subscribe[SubscriptionRemoved](HandlerFunc[SubscriptionRemoved](func(event SubscriptionRemoved) {
+		recordMu.Lock()
+		defer recordMu.Unlock()
+		removed = append(removed, event)
+	}))
+
+	invoked := 0
+	id := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		invoked++
+		panic("broken")
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, Publish(progressEvent{Count: i}), ErrHandlerPanic)
+	}
+	assert.Equal(t, 3, invoked)
+
+	// The handler is skipped right away and unsubscribed shortly after
+	assert.NoError(t, Publish(progressEvent{Count: 3}))
+	assert.Equal(t, 3, invoked)
+	assert.Eventually(t, func() bool {
+		recordMu.Lock()
+		defer recordMu.Unlock()
+		return len(reported) > 0
+	}, time.Second, time.Millisecond)
+	assert.Len(t, handlers[reflect.TypeOf(progressEvent{})], 1)
+
+	recordMu.Lock()
+	defer recordMu.Unlock()
+	assert.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], ErrHandlerRemoved)
+	assert.Equal(t, []SubscriptionRemoved{{Type: reflect.TypeOf(progressEvent{}), ID: id}}, removed)
+}
+
+func TestWithAutoRemoveOnPanic_ResetOnSuccess(t *testing.T) {
+	reset()
+	Configure(WithAutoRemoveOnPanic(2))
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		if event.Count%2 == 0 {
+			panic("flaky")
+		}
+	}))
+	for i := 0; i < 6; i++ {
+		_ = Publish(progressEvent{Count: i})
+	}
+	assert.Len(t, handlers[reflect.TypeOf(progressEvent{})], 1)
+}
@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// BalanceStrategy determines how PublishBalanced selects the handler that
+// receives an event.
+type BalanceStrategy int
+
+const (
+	// RoundRobin cycles through the registered handlers in registration order.
+	RoundRobin BalanceStrategy = iota
+	// Random selects a handler at random for each event.
+	Random
+)
+
+// balanceCursors tracks the round-robin position for each event type. It is a
+// sync.Map since PublishBalanced only holds the read lock.
+var balanceCursors = sync.Map{}
+
+// PublishBalanced sends an event to exactly one of the handlers registered for
+// the event type rather than all of them. The handler is selected using the
+// BalanceStrategy configured with WithBalanceStrategy, which defaults to
+// RoundRobin. This effectively turns the handlers for a type into a pool of
+// workers. If the selected handler is an ErrorHandler its error is returned.
+// Suspended handlers, handlers disabled by their gate and fallback handlers are
+// not considered by PublishBalanced, if no active handlers are registered for the
+// event type an error is returned. Otherwise the event is published like with
+// Publish: it is rate limited, filtered, appended, recorded, buffered while the
+// type is paused and the selected handler is invoked with its panics recovered
+// and its invocation measured. Raw handlers don't receive it.
+func PublishBalanced[T any](event T) error {
+	return publish(event, delivery{balanced: true})
+}
+
+// dispatchBalanced invokes the active handler selected by the balance strategy.
+// The caller must hold the read lock.
+func (d delivery) dispatchBalanced(eventType reflect.Type, handler []handlerEntry, event any) error {
+	handler = activeEntries(handler)
+	if len(handler) == 0 {
+		return fmt.Errorf("no handler for event %s", typeName(eventType))
+	}
+
+	var idx int
+	switch cfg.balanceStrategy {
+	case Random:
+		idx = rand.Intn(len(handler))
+	default:
+		cursor, _ := balanceCursors.LoadOrStore(eventType, new(uint64))
+		next := atomic.AddUint64(cursor.(*uint64), 1) - 1
+		idx = int(next % uint64(len(handler)))
+	}
+	return d.invoke(eventType, handler[idx], event)
+}
@@ -0,0 +1,76 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishBalanced_RoundRobin(t *testing.T) {
+	reset()
+	counts := make([]int, 4)
+	for i := range counts {
+		i := i
+		Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+			counts[i]++
+		}))
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, PublishBalanced(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	}
+	assert.Equal(t, []int{25, 25, 25, 25}, counts)
+}
+
+func TestPublishBalanced_Random(t *testing.T) {
+	reset()
+	Configure(WithBalanceStrategy(Random))
+	counts := make([]int, 4)
+	for i := range counts {
+		i := i
+		Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+			counts[i]++
+		}))
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, PublishBalanced(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	}
+	assert.Equal(t, 100, counts[0]+counts[1]+counts[2]+counts[3])
+}
+
+func TestPublishBalanced_NoHandler(t *testing.T) {
+	reset()
+	assert.Error(t, PublishBalanced(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+}
+
+func TestPublishBalanced_Paused(t *testing.T) {
+	reset()
+	Configure(WithSkipZeroValue())
+	counts := make([]int, 2)
+	for i := range counts {
+		i := i
+		Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+			counts[i]++
+		}))
+	}
+
+	// Zero values are skipped and events are buffered while the type is paused
+	assert.NoError(t, PublishBalanced(userCreatedEvent{}))
+	Pause[userCreatedEvent]()
+	assert.NoError(t, PublishBalanced(userCreatedEvent{Name: "John Doe"}))
+	assert.NoError(t, PublishBalanced(userCreatedEvent{Name: "Jane Doe"}))
+	assert.Equal(t, []int{0, 0}, counts)
+	assert.NoError(t, Resume[userCreatedEvent]())
+	assert.Equal(t, []int{1, 1}, counts)
+}
+
+func TestPublishBalanced_Panic(t *testing.T) {
+	reset()
+	Configure(WithPanicEvents())
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		panic("boom")
+	}))
+
+	assert.ErrorIs(t, PublishBalanced(userCreatedEvent{Name: "John Doe"}), ErrHandlerPanic)
+}
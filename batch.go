@@ -0,0 +1,88 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// AsyncBatch groups events published asynchronously so the caller can wait once
+// for the handlers of all of them to return, rather than for each event on its
+// own. It is created with NewAsyncBatch.
+//
+//	b := eventbus.NewAsyncBatch()
+//	for _, order := range orders {
+//		if err := b.PublishAsync(OrderPlaced{ID: order.ID}); err != nil {
+//			// handle error
+//		}
+//	}
+//	err := b.Wait(ctx)
+type AsyncBatch struct {
+	pending inflightCounter
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewAsyncBatch returns an empty AsyncBatch.
+func NewAsyncBatch() *AsyncBatch {
+	return &AsyncBatch{}
+}
+
+// PublishAsync publishes the event asynchronously as part of the batch, finding
+// its handlers by the dynamic type of the event like PublishDynamic. It returns
+// the same errors as PublishAsync, while the errors returned by the handlers are
+// collected by the batch as well as being passed to the error callback. Handler
+// invocations of the batch are never spilled to the store set with WithSpillover,
+// and events buffered because their type is paused aren't waited for.
+func (b *AsyncBatch) PublishAsync(event any) error {
+	return publishAsync(event, delivery{ctx: context.Background(), batch: b})
+}
+
+// Wait blocks until the handlers invoked for every event published to the batch
+// so far have returned, or were shed or dropped, and returns the errors they
+// returned joined together. If the context is done first the context's error is
+// returned.
+func (b *AsyncBatch) Wait(ctx context.Context) error {
+	select {
+	case <-b.pending.wait():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return errors.Join(b.errs...)
+}
+
+func (b *AsyncBatch) add() {
+	if b != nil {
+		b.pending.tryAdd(0)
+	}
+}
+
+func (b *AsyncBatch) done() {
+	if b != nil {
+		b.pending.done()
+	}
+}
+
+func (b *AsyncBatch) fail(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.errs = append(b.errs, err)
+}
+
+// reportAsync reports the error of a handler invoked asynchronously to the batch
+// of the delivery, if any, and to the error callback.
+func (d delivery) reportAsync(callback func(err error), err error) {
+	d.batch.fail(err)
+	if callback != nil {
+		callback(err)
+	}
+}
@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncBatch(t *testing.T) {
+	reset()
+	errOdd := errors.New("odd")
+	var finished atomic.Int32
+	SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(event progressEvent) error {
+		defer finished.Add(1)
+		time.Sleep(time.Duration(event.Count) * 5 * time.Millisecond)
+		if event.Count%2 == 1 {
+			return fmt.Errorf("%w: %d", errOdd, event.Count)
+		}
+		return nil
+	}))
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		time.Sleep(10 * time.Millisecond)
+		finished.Add(1)
+	}))
+
+	b := NewAsyncBatch()
+	for i := 1; i <= 4; i++ {
+		assert.NoError(t, b.PublishAsync(progressEvent{Count: i}))
+	}
+	assert.NoError(t, b.PublishAsync(orderEvent{Seq: 1}))
+
+	err := b.Wait(context.Background())
+	assert.Equal(t, int32(5), finished.Load())
+	assert.ErrorIs(t, err, errOdd)
+	assert.ErrorContains(t, err, "odd: 1")
+	assert.ErrorContains(t, err, "odd: 3")
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+}
+
+func TestAsyncBatch_Timeout(t *testing.T) {
+	reset()
+	release := make(chan struct{})
+	defer close(release)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		<-release
+	}))
+
+	b := NewAsyncBatch()
+	assert.NoError(t, b.PublishAsync(progressEvent{Count: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestAsyncBatch_Empty(t *testing.T) {
+	reset()
+	assert.NoError(t, NewAsyncBatch().Wait(context.Background()))
+}
@@ -0,0 +1,216 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// forwarder is implemented by handlers that forward events to a remote bus.
+// Events received from a remote bus are never dispatched to forwarders.
+type forwarder interface {
+	forwardsRemote()
+}
+
+var (
+	jsonNames = make(map[reflect.Type]string)
+	jsonTypes = make(map[string]func(codec Codec, payload []byte) error)
+)
+
+// Codec encodes and decodes the events sent through a RemoteBridge.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is the Codec encoding events with encoding/json. It is the default
+// codec of a RemoteBridge.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// BridgeOption configures a RemoteBridge.
+type BridgeOption func(b *RemoteBridge)
+
+// WithCodec sets the Codec a RemoteBridge encodes and decodes events with, such
+// as one using Protobuf, msgpack or gob, rather than encoding/json. Both ends of
+// the bridge must use the same codec. Events are still framed in JSON envelopes
+// naming their type, and payloads produced by a codec other than JSONCodec are
+// embedded in the envelope base64 encoded.
+func WithCodec(codec Codec) BridgeOption {
+	return func(b *RemoteBridge) {
+		b.codec = codec
+	}
+}
+
+// RegisterJSON registers an event type under a name that identifies the type
+// when it is sent to or received from a remote bus through a RemoteBridge. The
+// event type is encoded using the Codec of the bridge, encoding/json unless
+// another is set with WithCodec, and the same name must be registered for the
+// type on both ends of the bridge.
+func RegisterJSON[T any](name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	jsonNames[reflect.TypeOf(*new(T))] = name
+	jsonTypes[name] = func(codec Codec, payload []byte) error {
+		var event T
+		if err := codec.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("eventbus: decode %s: %w", name, err)
+		}
+		return publish(event, delivery{remote: true})
+	}
+}
+
+type remoteEnvelope struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// RemoteBridge connects eventbus to a bus running in another process over a
+// transport supplied by the caller, such as a net.Conn. Events of the types
+// passed to Forward are written to the remote bus, and events read from the
+// remote bus by Receive are published locally. Events received from the remote
+// bus are not forwarded back, preventing events from looping between the buses.
+type RemoteBridge struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	dec   *json.Decoder
+	codec Codec
+}
+
+// NewRemoteBridge creates a RemoteBridge that writes events forwarded to the
+// remote bus to w and reads events from the remote bus from r. Either may be
+// nil if the bridge is only used in one direction.
+func NewRemoteBridge(w io.Writer, r io.Reader, opts ...BridgeOption) *RemoteBridge {
+	b := &RemoteBridge{codec: JSONCodec{}}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if w != nil {
+		b.enc = json.NewEncoder(w)
+	}
+	if r != nil {
+		b.dec = json.NewDecoder(r)
+	}
+	return b
+}
+
+type bridgeForwarder[T any] struct {
+	bridge *RemoteBridge
+	name   string
+}
+
+func (f *bridgeForwarder[T]) OnEvent(event T) error {
+	return f.bridge.send(f.name, event)
+}
+
+func (f *bridgeForwarder[T]) forwardsRemote() {}
+
+// Forward subscribes a handler that forwards events of the given type to the
+// remote bus. The type must have been registered with RegisterJSON. Errors
+// writing the event to the remote bus are returned from Publish. The return
+// value is a subscription ID that can be used to stop forwarding with
+// Unsubscribe.
+func Forward[T any](bridge *RemoteBridge) (uint64, error) {
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	name, ok := jsonNames[eventType]
+	if !ok {
+		return 0, fmt.Errorf("eventbus: event type %s is not registered with RegisterJSON", eventType)
+	}
+	f := &bridgeForwarder[T]{bridge: bridge, name: name}
+	return subscribe(eventType, f, errorHandlerInvoker[T](f), 2), nil
+}
+
+func (b *RemoteBridge) send(name string, event any) error {
+	if b.enc == nil {
+		return errors.New("eventbus: remote bridge has no writer")
+	}
+
+	payload, err := b.codec.Marshal(event)
+	if err == nil {
+		payload, err = b.embed(payload)
+	}
+	if err != nil {
+		return fmt.Errorf("eventbus: encode %s: %w", name, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.enc.Encode(remoteEnvelope{Type: name, Payload: payload})
+}
+
+// Receive reads events from the remote bus and publishes them locally until the
+// reader returns io.EOF, in which case Receive returns nil, or another error
+// which Receive returns. Events of types that haven't been registered with
+// RegisterJSON, and errors publishing received events, are routed to the error
+// callback and don't stop Receive. Receive is typically called in its own
+// goroutine.
+func (b *RemoteBridge) Receive() error {
+	if b.dec == nil {
+		return errors.New("eventbus: remote bridge has no reader")
+	}
+
+	for {
+		var envelope remoteEnvelope
+		if err := b.dec.Decode(&envelope); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		mu.RLock()
+		publishFn, ok := jsonTypes[envelope.Type]
+		callback := cfg.errorCallback
+		mu.RUnlock()
+
+		var err error
+		if ok {
+			var payload []byte
+			if payload, err = b.extract(envelope.Payload); err == nil {
+				err = publishFn(b.codec, payload)
+			} else {
+				err = fmt.Errorf("eventbus: decode %s: %w", envelope.Type, err)
+			}
+		} else {
+			err = fmt.Errorf("eventbus: received event type %q is not registered with RegisterJSON", envelope.Type)
+		}
+		if err != nil && callback != nil {
+			callback(err)
+		}
+	}
+}
+
+// embed returns the payload encoded by the codec as the payload of an envelope.
+// Payloads encoded by JSONCodec are embedded as is so the envelope stays
+// readable, other payloads are embedded as a base64 encoded JSON string.
+func (b *RemoteBridge) embed(payload []byte) (json.RawMessage, error) {
+	if _, ok := b.codec.(JSONCodec); ok {
+		return payload, nil
+	}
+	return json.Marshal(payload)
+}
+
+// extract returns the payload to decode with the codec from the payload of an
+// envelope, reversing embed.
+func (b *RemoteBridge) extract(payload json.RawMessage) ([]byte, error) {
+	if _, ok := b.codec.(JSONCodec); ok {
+		return payload, nil
+	}
+	var data []byte
+	err := json.Unmarshal(payload, &data)
+	return data, err
+}
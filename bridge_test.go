@@ -0,0 +1,167 @@
+package eventbus
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForward(t *testing.T) {
+	reset()
+	RegisterJSON[userCreatedEvent]("user.created")
+
+	var out bytes.Buffer
+	_, err := Forward[userCreatedEvent](NewRemoteBridge(&out, nil))
+	assert.NoError(t, err)
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	assert.Equal(t, `{"type":"user.created","payload":{"Name":"John Doe","Email":"jdoe@gmail.com"}}`+"\n", out.String())
+}
+
+func TestForward_NotRegistered(t *testing.T) {
+	reset()
+	_, err := Forward[userCreatedEvent](NewRemoteBridge(&bytes.Buffer{}, nil))
+	assert.Error(t, err)
+}
+
+func TestRemoteBridge_Receive(t *testing.T) {
+	reset()
+	RegisterJSON[userCreatedEvent]("user.created")
+	var errs []error
+	Configure(WithErrorCallback(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}).Return()
+	Subscribe[userCreatedEvent](h)
+
+	var out bytes.Buffer
+	in := strings.NewReader(`{"type":"user.created","payload":{"Name":"John Doe","Email":"jdoe@gmail.com"}}
+{"type":"user.deleted","payload":{}}
+`)
+	bridge := NewRemoteBridge(&out, in)
+	_, err := Forward[userCreatedEvent](bridge)
+	assert.NoError(t, err)
+
+	assert.NoError(t, bridge.Receive())
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+	assert.Len(t, errs, 1)
+
+	// Received events must not be forwarded back to the remote bus
+	assert.Empty(t, out.String())
+}
+
+func TestRemoteBridge_Pipe(t *testing.T) {
+	reset()
+	RegisterJSON[userCreatedEvent]("user.created")
+
+	var (
+		lock     sync.Mutex
+		received []userCreatedEvent
+	)
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, event)
+	}))
+
+	// Both ends of the pipe are bridged to the same in process bus and both forward
+	// the event type. The handler receives the published event, plus the copy each
+	// bridge forwarded, but the received copies are never forwarded again.
+	local, remote := net.Pipe()
+	localBridge := NewRemoteBridge(local, local)
+	remoteBridge := NewRemoteBridge(remote, remote)
+	_, err := Forward[userCreatedEvent](localBridge)
+	assert.NoError(t, err)
+	_, err = Forward[userCreatedEvent](remoteBridge)
+	assert.NoError(t, err)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = localBridge.Receive()
+	}()
+	go func() {
+		defer wg.Done()
+		_ = remoteBridge.Receive()
+	}()
+
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, Publish(event))
+	}()
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(received) >= 3
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	local.Close()
+	remote.Close()
+	wg.Wait()
+	assert.Equal(t, []userCreatedEvent{event, event, event}, received)
+}
+
+// reversingCodec is a fake codec encoding values as reversed JSON, so its
+// payloads aren't valid JSON.
+type reversingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *reversingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals++
+	data, err := json.Marshal(v)
+	slices.Reverse(data)
+	return data, err
+}
+
+func (c *reversingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	data = slices.Clone(data)
+	slices.Reverse(data)
+	return json.Unmarshal(data, v)
+}
+
+func TestRemoteBridge_WithCodec(t *testing.T) {
+	reset()
+	RegisterJSON[userCreatedEvent]("user.created")
+	var errs []error
+	Configure(WithErrorCallback(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	codec := &reversingCodec{}
+	var wire bytes.Buffer
+	_, err := Forward[userCreatedEvent](NewRemoteBridge(&wire, nil, WithCodec(codec)))
+	assert.NoError(t, err)
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	assert.NoError(t, Publish(event))
+	assert.Equal(t, 1, codec.marshals)
+
+	// Receive the event on a fresh bus so it isn't forwarded again
+	reset()
+	RegisterJSON[userCreatedEvent]("user.created")
+	Configure(WithErrorCallback(func(err error) {
+		errs = append(errs, err)
+	}))
+	var received []userCreatedEvent
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		received = append(received, event)
+	}))
+	assert.NoError(t, NewRemoteBridge(nil, &wire, WithCodec(codec)).Receive())
+	assert.Equal(t, 1, codec.unmarshals)
+	assert.Equal(t, []userCreatedEvent{event}, received)
+	assert.Empty(t, errs)
+}
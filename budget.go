@@ -0,0 +1,35 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBudgetExceeded is returned by PublishWithBudget when handlers were skipped
+// because the budget for the publish ran out.
+var ErrBudgetExceeded = errors.New("eventbus: publish budget exceeded")
+
+// PublishWithBudget behaves like Publish but stops invoking further handlers once
+// the handlers invoked so far have taken longer than budget combined, bounding
+// the worst case latency of publishing to many handlers. Handlers are not
+// interrupted, so the publish may take longer than budget by the time taken by
+// the last handler invoked. If handlers were skipped an error wrapping
+// ErrBudgetExceeded listing the subscription IDs of the handlers that ran and
+// that were skipped is returned, joined with any errors of the handlers that
+// ran. Fallback handlers are not subject to the budget.
+func PublishWithBudget[T any](event T, budget time.Duration) error {
+	return publish(event, delivery{budgetEnds: now().Add(budget)})
+}
+
+// budgetExceeded returns the error for a publish whose budget ran out after the
+// handlers with the ran IDs, before the remaining entries were invoked.
+func budgetExceeded(ran []uint64, remaining []handlerEntry, d delivery) error {
+	skipped := make([]uint64, 0, len(remaining))
+	for _, h := range remaining {
+		if !d.skipEntry(h) {
+			skipped = append(skipped, h.id)
+		}
+	}
+	return fmt.Errorf("%w: ran subscriptions %v, skipped subscriptions %v", ErrBudgetExceeded, ran, skipped)
+}
@@ -0,0 +1,40 @@
+package eventbus
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishWithBudget(t *testing.T) {
+	reset()
+	var invoked []int
+	ids := make([]uint64, 0, 4)
+	for i := 0; i < 4; i++ {
+		i := i
+		ids = append(ids, Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+			invoked = append(invoked, i)
+			time.Sleep(50 * time.Millisecond)
+		})))
+	}
+
+	err := PublishWithBudget(progressEvent{Count: 1}, 75*time.Millisecond)
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+	assert.Equal(t, []int{0, 1}, invoked)
+	assert.EqualError(t, err, fmt.Sprintf("eventbus: publish budget exceeded: ran subscriptions %v, skipped subscriptions %v", ids[:2], ids[2:]))
+}
+
+func TestPublishWithBudget_WithinBudget(t *testing.T) {
+	reset()
+	invoked := 0
+	for i := 0; i < 3; i++ {
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+			invoked++
+		}))
+	}
+
+	assert.NoError(t, PublishWithBudget(progressEvent{Count: 1}, time.Second))
+	assert.Equal(t, 3, invoked)
+}
@@ -0,0 +1,110 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	// bulkheadsMu guards bulkheads, which pools are added to while holding the
+	// read lock.
+	bulkheadsMu sync.Mutex
+
+	// bulkheads holds the worker pool of each event type under WithBulkheads,
+	// created when an invocation for the type is first submitted.
+	bulkheads = make(map[reflect.Type]*workerPool)
+)
+
+// TypeHealth is a point-in-time snapshot of the asynchronous handler invocations
+// for an event type, see HealthByType.
+type TypeHealth struct {
+	// Queued is the number of invocations submitted that haven't started running.
+	Queued int
+	// Running is the number of invocations running.
+	Running int
+	// Saturated is true if every worker of the worker pool running invocations for
+	// the type is busy, so further invocations wait in the queue. With
+	// WithBulkheads only the workers of the pool of the type are considered. It is
+	// always false without a worker pool.
+	Saturated bool
+}
+
+// HealthByType returns the health of the asynchronous handler invocations for
+// events of the given type. A type whose invocations are queued while none of
+// them is able to finish, such as because its handler is wedged, is reported as
+// saturated with a growing queue.
+func HealthByType(eventType reflect.Type) TypeHealth {
+	queued := QueueDepthByType(eventType)
+	health := TypeHealth{
+		Queued:  queued,
+		Running: typeInflight(eventType).count() - queued,
+	}
+
+	mu.RLock()
+	p := pool
+	if p != nil && cfg.bulkheads {
+		bulkheadsMu.Lock()
+		p = bulkheads[eventType]
+		bulkheadsMu.Unlock()
+	}
+	mu.RUnlock()
+
+	if p != nil {
+		health.Saturated = p.saturated()
+	}
+	return health
+}
+
+// poolFor returns the worker pool invocations for events of the given type are
+// queued on, creating the pool of the type under WithBulkheads, or nil if no
+// worker pool is configured. The caller must hold the read lock.
+func poolFor(eventType reflect.Type) *workerPool {
+	if pool == nil || !cfg.bulkheads {
+		return pool
+	}
+
+	bulkheadsMu.Lock()
+	defer bulkheadsMu.Unlock()
+
+	p, ok := bulkheads[eventType]
+	if !ok {
+		p = newWorkerPool(pool.workers, pool.capacity)
+		p.setBlocking(cfg.blockOnQueueFull)
+		bulkheads[eventType] = p
+	}
+	return p
+}
+
+// workerPools returns the worker pool and the pools of the bulkheads. The caller
+// must hold the read lock.
+func workerPools() []*workerPool {
+	if pool == nil {
+		return nil
+	}
+
+	bulkheadsMu.Lock()
+	defer bulkheadsMu.Unlock()
+
+	pools := []*workerPool{pool}
+	for _, p := range bulkheads {
+		pools = append(pools, p)
+	}
+	return pools
+}
+
+// configureBulkheads stops the pools of the bulkheads once bulkheads are disabled
+// or the size of the worker pool changes, letting them run the invocations
+// already queued. The caller must hold the write lock.
+func configureBulkheads() {
+	bulkheadsMu.Lock()
+	defer bulkheadsMu.Unlock()
+
+	for eventType, p := range bulkheads {
+		if pool == nil || !cfg.bulkheads || p.workers != pool.workers || p.capacity != pool.capacity {
+			p.stop()
+			delete(bulkheads, eventType)
+			continue
+		}
+		p.setBlocking(cfg.blockOnQueueFull)
+	}
+}
@@ -0,0 +1,50 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBulkheads(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 4), WithBulkheads())
+
+	// Wedge the handler of userCreatedEvent so its only worker is busy and further
+	// invocations queue up behind it
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{}, 2)
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		started <- struct{}{}
+		<-release
+	}))
+	received := make(chan progressEvent, 1)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received <- event
+	}))
+
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	<-started
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "Jane Doe"}))
+
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	select {
+	case event := <-received:
+		assert.Equal(t, progressEvent{Count: 1}, event)
+	case <-time.After(time.Second):
+		t.Fatal("handler was blocked by the wedged handler of another type")
+	}
+
+	assert.Equal(t, TypeHealth{Queued: 1, Running: 1, Saturated: true}, HealthByType(reflect.TypeOf(userCreatedEvent{})))
+	assert.Eventually(t, func() bool {
+		return HealthByType(reflect.TypeOf(progressEvent{})) == TypeHealth{}
+	}, time.Second, time.Millisecond)
+}
+
+func TestHealthByType_NoPool(t *testing.T) {
+	reset()
+	assert.Equal(t, TypeHealth{}, HealthByType(reflect.TypeOf(progressEvent{})))
+}
@@ -0,0 +1,100 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+type causeKey struct{}
+
+var (
+	// eventIDs is the last ID assigned to an event published while causal tracing
+	// is enabled with WithCausalTracing.
+	eventIDs atomic.Uint64
+
+	causesMu = sync.Mutex{}
+	// causes holds, for each goroutine dispatching events, the IDs of the events
+	// being dispatched with the innermost last.
+	causes = make(map[uint64][]uint64)
+)
+
+// CauseID returns the ID of the event being handled by a ContextHandler, which is
+// passed the context, and whether there is one. Events published with a context
+// carrying the ID, such as with PublishAsyncCtx, record it as their cause. IDs are
+// only assigned when causal tracing is enabled with WithCausalTracing.
+func CauseID(ctx context.Context) (uint64, bool) {
+	id, ok := ctx.Value(causeKey{}).(uint64)
+	return id, ok
+}
+
+// traced assigns the delivery an event ID and records the event being handled by
+// the publishing goroutine, or carried by the context of the delivery, as its
+// cause if causal tracing is enabled. The caller must hold the read lock.
+func (d delivery) traced() delivery {
+	if !cfg.causalTracing {
+		return d
+	}
+	if d.ctx != nil {
+		d.causeID, _ = CauseID(d.ctx)
+	}
+	if d.causeID == 0 {
+		d.causeID = currentCause()
+	}
+	d.eventID = eventIDs.Add(1)
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d.ctx = context.WithValue(ctx, causeKey{}, d.eventID)
+	return d
+}
+
+// currentCause returns the ID of the innermost event being dispatched on the
+// current goroutine, or zero if there is none.
+func currentCause() uint64 {
+	id := goroutineID()
+	causesMu.Lock()
+	defer causesMu.Unlock()
+
+	if stack := causes[id]; len(stack) > 0 {
+		return stack[len(stack)-1]
+	}
+	return 0
+}
+
+// enterCause records that the event with the given ID is being dispatched on the
+// current goroutine, so events published by its handlers record it as their
+// cause, returning a function that removes the record again.
+func enterCause(eventID uint64) func() {
+	if eventID == 0 {
+		return func() {}
+	}
+
+	id := goroutineID()
+	causesMu.Lock()
+	causes[id] = append(causes[id], eventID)
+	causesMu.Unlock()
+
+	return func() {
+		causesMu.Lock()
+		defer causesMu.Unlock()
+
+		stack := causes[id]
+		if len(stack) <= 1 {
+			delete(causes, id)
+			return
+		}
+		causes[id] = stack[:len(stack)-1]
+	}
+}
+
+// causing wraps invoke so events published by the handler on its asynchronous
+// task record the event of the delivery as their cause.
+func (d delivery) causing(invoke func(event any) error) func(event any) error {
+	return func(event any) error {
+		defer enterCause(d.eventID)()
+		return invoke(event)
+	}
+}
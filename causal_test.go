@@ -0,0 +1,88 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCausalTracing(t *testing.T) {
+	reset()
+	Configure(WithAuditLog(10), WithCausalTracing())
+
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		assert.NoError(t, Publish(progressEvent{Count: 1}))
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {}))
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+
+	log := AuditLog()
+	if assert.Len(t, log, 3) {
+		assert.Equal(t, userCreatedEvent{Name: "John Doe"}, log[0].Event)
+		assert.NotZero(t, log[0].ID)
+		assert.Zero(t, log[0].CauseID)
+		assert.Equal(t, progressEvent{Count: 1}, log[1].Event)
+		assert.Equal(t, log[0].ID, log[1].CauseID)
+		// Events published after the handler returned aren't linked to its event
+		assert.Zero(t, log[2].CauseID)
+	}
+}
+
+func TestWithCausalTracing_Async(t *testing.T) {
+	reset()
+	Configure(WithAuditLog(10), WithCausalTracing())
+
+	SubscribeContext[userCreatedEvent](ContextHandlerFunc[userCreatedEvent](func(ctx context.Context, event userCreatedEvent) error {
+		// Publishing from another goroutine links the event through the context
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.NoError(t, PublishAsyncCtx(ctx, progressEvent{Count: 1}))
+		}()
+		<-done
+		return nil
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {}))
+
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	assert.Eventually(t, func() bool {
+		return len(AuditLog()) == 2 && ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+
+	log := AuditLog()
+	assert.Equal(t, log[0].ID, log[1].CauseID)
+}
+
+func TestCauseID_Disabled(t *testing.T) {
+	reset()
+	traced := true
+	SubscribeContext[userCreatedEvent](ContextHandlerFunc[userCreatedEvent](func(ctx context.Context, event userCreatedEvent) error {
+		_, traced = CauseID(ctx)
+		return nil
+	}))
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.False(t, traced)
+}
+
+func TestWithCausalTracing_AsyncHandler(t *testing.T) {
+	reset()
+	Configure(WithAuditLog(10), WithCausalTracing())
+
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		assert.NoError(t, Publish(progressEvent{Count: 1}))
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {}))
+
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	assert.Eventually(t, func() bool {
+		return len(AuditLog()) == 2 && ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+
+	log := AuditLog()
+	assert.Equal(t, log[0].ID, log[1].CauseID)
+}
@@ -0,0 +1,85 @@
+package eventbus
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the time and creates timers for the time based features of the
+// bus, such as the TTL of PublishAsyncTTL, the dedup window of PublishOnce, the
+// window of SubscribeCoalesced, the interval of PublishEvery and the rate limits
+// set with SetRateLimit. Replacing it with WithClock allows tests to control time
+// with a fake clock, such as the one provided by the eventbustest package, rather
+// than sleeping. Durations reported to a MetricsCollector are always measured
+// with the real clock.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer created by a Clock, which behaves like time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// clock holds the Clock set with WithClock, or nil for the real clock. Timers and
+// rate limiting read it without holding the lock, so Configure stores it
+// atomically.
+var clock atomic.Pointer[Clock]
+
+// currentClock returns the Clock set with WithClock, or the real clock.
+func currentClock() Clock {
+	if c := clock.Load(); c != nil {
+		return *c
+	}
+	return realClock{}
+}
+
+// now returns the current time of the Clock set with WithClock.
+func now() time.Time {
+	return currentClock().Now()
+}
+
+// configureClock stores the clock of the configuration. The caller must hold the
+// write lock.
+func configureClock() {
+	if cfg.clock == nil {
+		clock.Store(nil)
+		return
+	}
+	c := cfg.clock
+	clock.Store(&c)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t realTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
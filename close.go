@@ -0,0 +1,145 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Closer is implemented by handlers that need to release resources or flush
+// buffered work when the bus shuts down. See CloseHandlers.
+type Closer interface {
+	Close() error
+}
+
+// inflight tracks the handler goroutines started by PublishAsync which haven't
+// returned yet.
+var inflight = &inflightCounter{}
+
+// inflightCounter counts running goroutines and signals when none are running.
+// Unlike sync.WaitGroup it may be waited on while goroutines are being added.
+type inflightCounter struct {
+	mu   sync.Mutex
+	n    int
+	idle chan struct{}
+}
+
+// tryAdd adds a goroutine unless max goroutines are already running, returning
+// whether it was added. A max of zero or less is unlimited.
+func (c *inflightCounter) tryAdd(max int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if max > 0 && c.n >= max {
+		return false
+	}
+	if c.n == 0 {
+		c.idle = make(chan struct{})
+	}
+	c.n++
+	return true
+}
+
+func (c *inflightCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.n
+}
+
+func (c *inflightCounter) done() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.n--
+	if c.n == 0 {
+		close(c.idle)
+	}
+}
+
+// wait returns a channel which is closed when no goroutines are running.
+func (c *inflightCounter) wait() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.n == 0 {
+		idle := make(chan struct{})
+		close(idle)
+		return idle
+	}
+	return c.idle
+}
+
+// CloseHandlers shuts down the bus by stopping periodic publishes started with
+// PublishEvery, waiting for handlers invoked by PublishAsync to return, including
+// those queued for the worker pool, unsubscribing every handler, fallback
+// handler and raw handler, and then calling Close on each handler implementing Closer in reverse
+// registration order, so handlers registered last are closed first. This gives
+// handlers the chance to flush buffered work in an order that respects their
+// dependencies, such as writers before the connections they write to. Errors
+// returned by Close are joined and returned once every handler has been closed.
+//
+// If the context is done before in flight asynchronous handlers return the
+// context's error is returned and no handlers are unsubscribed or closed,
+// although periodic publishes remain stopped. Once the handlers have been
+// unsubscribed draining started by Drain or DrainType ends, so the bus accepts
+// publishes again.
+func CloseHandlers(ctx context.Context) error {
+	stopPeriodics()
+
+	select {
+	case <-inflight.wait():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	mu.RLock()
+	pools := workerPools()
+	mu.RUnlock()
+	for _, p := range pools {
+		select {
+		case <-p.pending.wait():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	mu.Lock()
+	var entries []handlerEntry
+	for eventType, entry := range handlers {
+		for _, h := range entry {
+			release(eventType, h)
+		}
+		entries = append(entries, entry...)
+	}
+	handlers = make(map[reflect.Type][]handlerEntry)
+	for range fallbacks {
+		recordUnsubscribe()
+	}
+	fallbacks = make([]fallbackEntry, 0)
+	for range rawHandlers {
+		recordUnsubscribe()
+	}
+	rawHandlers = make([]rawEntry, 0)
+	draining = false
+	drainedTypes = make(map[reflect.Type]bool)
+	unlockAndNotify()
+
+	// Subscription IDs are assigned in increasing order, so sorting by ID in
+	// descending order gives the reverse registration order across event types.
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id > entries[j].id
+	})
+
+	var errs []error
+	for _, entry := range entries {
+		if c, ok := entry.handler.(Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
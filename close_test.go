@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closingHandler[T any] struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (h *closingHandler[T]) OnEvent(T) {}
+
+func (h *closingHandler[T]) Close() error {
+	*h.closed = append(*h.closed, h.name)
+	return h.err
+}
+
+func TestCloseHandlers(t *testing.T) {
+	reset()
+	var closed []string
+	closeErr := errors.New("flush failed")
+	Subscribe[progressEvent](&closingHandler[progressEvent]{name: "writer", closed: &closed})
+	Subscribe[userCreatedEvent](&closingHandler[userCreatedEvent]{name: "encoder", closed: &closed, err: closeErr})
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	Subscribe[progressEvent](&closingHandler[progressEvent]{name: "closer", closed: &closed})
+	SubscribeFallback(func(any) {})
+
+	err := CloseHandlers(context.Background())
+	assert.ErrorIs(t, err, closeErr)
+	assert.Equal(t, []string{"closer", "encoder", "writer"}, closed)
+	assert.Empty(t, Subscriptions[progressEvent]())
+	assert.Equal(t, uint64(0), Stats().Active)
+	assert.Error(t, Publish(progressEvent{Count: 1}))
+}
+
+func TestCloseHandlers_DrainsAsync(t *testing.T) {
+	reset()
+	var closed []string
+	var handled atomic.Bool
+	release := make(chan struct{})
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		<-release
+		handled.Store(true)
+	}))
+	Subscribe[progressEvent](&closingHandler[progressEvent]{name: "writer", closed: &closed})
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, CloseHandlers(ctx), context.DeadlineExceeded)
+	assert.Len(t, Subscriptions[progressEvent](), 2)
+	assert.Empty(t, closed)
+
+	close(release)
+	assert.NoError(t, CloseHandlers(context.Background()))
+	assert.True(t, handled.Load())
+	assert.Equal(t, []string{"writer"}, closed)
+}
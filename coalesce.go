@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// coalescingHandler buffers events and folds them into a single event using a
+// merge function, delivering the merged event to the underlying handler once
+// the window elapses.
+type coalescingHandler[T any] struct {
+	handler Handler[T]
+	merge   func(a, b T) T
+	window  time.Duration
+
+	mu      sync.Mutex
+	pending T
+	buffer  bool
+}
+
+func (c *coalescingHandler[T]) OnEvent(event T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.buffer {
+		c.pending = c.merge(c.pending, event)
+		return
+	}
+
+	c.pending = event
+	c.buffer = true
+	elapsed := currentClock().After(c.window)
+	go func() {
+		<-elapsed
+		c.flush()
+	}()
+}
+
+func (c *coalescingHandler[T]) flush() {
+	c.mu.Lock()
+	event := c.pending
+	c.pending = *new(T)
+	c.buffer = false
+	c.mu.Unlock()
+
+	c.handler.OnEvent(event)
+}
+
+// SubscribeCoalesced registers a handler for a given type that receives events
+// coalesced over a window of time. The first event received starts the window,
+// and any events received before the window elapses are folded into it using
+// the merge function, where a is the accumulated event and b is the newly
+// received event. Once the window elapses the handler is invoked with the merged
+// event in a new goroutine. The return value is a subscription ID that can be
+// used to unsubscribe the handler. Note that events already buffered when the
+// handler is unsubscribed will still be delivered once the window elapses.
+func SubscribeCoalesced[T any](handler Handler[T], merge func(a, b T) T, window time.Duration) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	c := &coalescingHandler[T]{
+		handler: handler,
+		merge:   merge,
+		window:  window,
+	}
+	return subscribe(reflect.TypeOf(*new(T)), c, handlerInvoker[T](c), 2)
+}
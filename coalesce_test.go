@@ -0,0 +1,46 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type progressEvent struct {
+	Count int
+}
+
+func TestSubscribeCoalesced(t *testing.T) {
+	reset()
+	received := make(chan progressEvent, 10)
+	SubscribeCoalesced[progressEvent](
+		HandlerFunc[progressEvent](func(event progressEvent) {
+			received <- event
+		}),
+		func(a, b progressEvent) progressEvent {
+			return progressEvent{Count: a.Count + b.Count}
+		},
+		100*time.Millisecond)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.NoError(t, Publish(progressEvent{Count: 3}))
+
+	select {
+	case event := <-received:
+		assert.Equal(t, progressEvent{Count: 6}, event)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for coalesced event")
+	}
+
+	// A new window starts with the next event
+	assert.NoError(t, Publish(progressEvent{Count: 4}))
+	select {
+	case event := <-received:
+		assert.Equal(t, progressEvent{Count: 4}, event)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for coalesced event")
+	}
+	assert.Empty(t, received)
+}
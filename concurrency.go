@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// concurrencyLimitedHandler bounds the number of concurrent invocations of the
+// underlying handler with a semaphore.
+type concurrencyLimitedHandler[T any] struct {
+	handler Handler[T]
+	sem     chan struct{}
+}
+
+func (c *concurrencyLimitedHandler[T]) OnEvent(event T) {
+	c.sem <- struct{}{}
+	defer func() { <-c.sem }()
+
+	c.handler.OnEvent(event)
+}
+
+// SubscribeWithConcurrency registers a handler for a given type that is invoked
+// by at most maxConcurrent goroutines at once, such as a handler using a
+// connection pool of that size. Invocations beyond the limit, typically from
+// bursts of events published with PublishAsync, block until a running invocation
+// returns, while other handlers aren't affected. This is finer grained than the
+// limits of WithWorkerPool and WithMaxAsyncGoroutines, which apply to every
+// handler, although blocked invocations still hold a worker of the pool.
+// SubscribeWithConcurrency panics if maxConcurrent is not greater than zero. The
+// return value is a subscription ID that can be used to unsubscribe the handler.
+func SubscribeWithConcurrency[T any](handler Handler[T], maxConcurrent int) uint64 {
+	mustNotBeNil(handler)
+	if maxConcurrent <= 0 {
+		panic("eventbus: SubscribeWithConcurrency requires a positive concurrency limit")
+	}
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	c := &concurrencyLimitedHandler[T]{
+		handler: handler,
+		sem:     make(chan struct{}, maxConcurrent),
+	}
+	return subscribe(reflect.TypeOf(*new(T)), c, handlerInvoker[T](c), 2)
+}
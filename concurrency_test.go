@@ -0,0 +1,43 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeWithConcurrency(t *testing.T) {
+	reset()
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	SubscribeWithConcurrency[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		defer wg.Done()
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+	}), 2)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestSubscribeWithConcurrency_InvalidLimit(t *testing.T) {
+	reset()
+	assert.Panics(t, func() {
+		SubscribeWithConcurrency[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}), 0)
+	})
+}
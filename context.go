@@ -0,0 +1,72 @@
+package eventbus
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// ContextHandler is a type capable of handling events published through eventbus
+// which accepts a context so it can abort its work when the context is done. The
+// context is the one passed to PublishAsyncCtx, or for PublishDeadline a child
+// of it which is cancelled after the per-handler timeout. Other publish
+// functions pass a background context.
+type ContextHandler[T any] interface {
+	OnEvent(ctx context.Context, event T) error
+}
+
+type ContextHandlerFunc[T any] func(ctx context.Context, event T) error
+
+func (f ContextHandlerFunc[T]) OnEvent(ctx context.Context, event T) error {
+	return f(ctx, event)
+}
+
+// SubscribeContext registers a ContextHandler for a given type. It behaves the
+// same as SubscribeErrorHandler except the handler also receives the context of
+// the publish. The return value is a subscription ID that can be used to
+// unsubscribe the handler with Unsubscribe.
+func SubscribeContext[T any](handler ContextHandler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	invokeCtx := func(ctx context.Context, event any) error {
+		return handler.OnEvent(ctx, event.(T))
+	}
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, func(event any) error {
+		return invokeCtx(context.Background(), event)
+	}, 2)
+	entryByID(eventType, id).invokeCtx = invokeCtx
+	return id
+}
+
+// PublishDeadline behaves like Publish but gives each ContextHandler its own
+// child of ctx which is cancelled once the handler has run for timeout. This lets
+// handlers cooperatively abort work that takes too long by watching ctx.Done,
+// rather than being abandoned. Handlers run one after another, so each handler's
+// deadline starts when it is invoked. Handlers that don't accept a context are
+// invoked as normal. If ctx is already done its error is returned and no
+// handlers are invoked.
+func PublishDeadline[T any](ctx context.Context, event T, timeout time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return publish(event, delivery{ctx: ctx, handlerTimeout: timeout})
+}
+
+// invokeContext invokes the ContextHandler of the entry with the context of the
+// delivery, bounded by the handler timeout if there is one.
+func (d delivery) invokeContext(entry handlerEntry, event any) error {
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if d.handlerTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.handlerTimeout)
+		defer cancel()
+	}
+	return entry.invokeCtx(ctx, event)
+}
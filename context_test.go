@@ -0,0 +1,78 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishDeadline(t *testing.T) {
+	reset()
+	var slowErr error
+	var slowElapsed time.Duration
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		start := time.Now()
+		select {
+		case <-ctx.Done():
+			slowElapsed = time.Since(start)
+			slowErr = ctx.Err()
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}))
+
+	fastCompleted := false
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		fastCompleted = ctx.Err() == nil
+		return nil
+	}))
+
+	plainInvoked := false
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		plainInvoked = true
+	}))
+
+	err := PublishDeadline(context.Background(), progressEvent{Count: 1}, 20*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, slowErr, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, slowElapsed, 20*time.Millisecond)
+	assert.Less(t, slowElapsed, time.Second)
+	assert.True(t, fastCompleted)
+	assert.True(t, plainInvoked)
+}
+
+func TestPublishDeadline_CancelledContext(t *testing.T) {
+	reset()
+	invoked := false
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(context.Context, progressEvent) error {
+		invoked = true
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, PublishDeadline(ctx, progressEvent{Count: 1}, time.Second), context.Canceled)
+	assert.False(t, invoked)
+}
+
+func TestSubscribeContext(t *testing.T) {
+	reset()
+	handlerErr := errors.New("failed")
+	received := make(chan context.Context, 1)
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		received <- ctx
+		return handlerErr
+	}))
+
+	assert.ErrorIs(t, Publish(progressEvent{Count: 1}), handlerErr)
+	assert.NoError(t, (<-received).Err())
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	assert.NoError(t, PublishAsyncCtx(ctx, progressEvent{Count: 2}))
+	assert.Equal(t, "value", (<-received).Value(key{}))
+}
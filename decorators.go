@@ -0,0 +1,40 @@
+package eventbus
+
+import (
+	"time"
+)
+
+// WithLogging decorates a handler so log is invoked with each event before the
+// handler handles it.
+func WithLogging[T any](handler Handler[T], log func(event T)) Handler[T] {
+	mustNotBeNil(handler)
+	return HandlerFunc[T](func(event T) {
+		log(event)
+		handler.OnEvent(event)
+	})
+}
+
+// WithRecover decorates a handler so a panic while handling an event is
+// recovered and passed to onPanic instead of propagating to the publisher.
+func WithRecover[T any](handler Handler[T], onPanic func(recovered any)) Handler[T] {
+	mustNotBeNil(handler)
+	return HandlerFunc[T](func(event T) {
+		defer func() {
+			if r := recover(); r != nil {
+				onPanic(r)
+			}
+		}()
+		handler.OnEvent(event)
+	})
+}
+
+// WithTiming decorates a handler so obs is invoked with how long the handler took
+// to handle each event. obs is not invoked if the handler panics.
+func WithTiming[T any](handler Handler[T], obs func(elapsed time.Duration)) Handler[T] {
+	mustNotBeNil(handler)
+	return HandlerFunc[T](func(event T) {
+		start := time.Now()
+		handler.OnEvent(event)
+		obs(time.Since(start))
+	})
+}
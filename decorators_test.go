@@ -0,0 +1,90 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogging(t *testing.T) {
+	reset()
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	h := new(userCreatedHandler)
+	h.On("OnEvent", event).Return()
+
+	var logged []userCreatedEvent
+	Subscribe[userCreatedEvent](WithLogging[userCreatedEvent](h, func(event userCreatedEvent) {
+		logged = append(logged, event)
+	}))
+
+	assert.NoError(t, Publish(event))
+	assert.Equal(t, []userCreatedEvent{event}, logged)
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestWithRecover(t *testing.T) {
+	reset()
+	var recovered any
+	Subscribe[progressEvent](WithRecover[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		panic("boom")
+	}), func(r any) {
+		recovered = r
+	}))
+
+	assert.NotPanics(t, func() {
+		assert.NoError(t, Publish(progressEvent{Count: 1}))
+	})
+	assert.Equal(t, "boom", recovered)
+}
+
+func TestWithTiming(t *testing.T) {
+	reset()
+	var elapsed time.Duration
+	Subscribe[progressEvent](WithTiming[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		time.Sleep(10 * time.Millisecond)
+	}), func(d time.Duration) {
+		elapsed = d
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.GreaterOrEqual(t, elapsed, 10*time.Millisecond)
+}
+
+func TestDecorators_Compose(t *testing.T) {
+	reset()
+	var steps []string
+	handler := HandlerFunc[progressEvent](func(event progressEvent) {
+		steps = append(steps, "handle")
+		if event.Count < 0 {
+			panic("negative count")
+		}
+	})
+
+	Subscribe[progressEvent](WithRecover(WithTiming(WithLogging[progressEvent](handler, func(progressEvent) {
+		steps = append(steps, "log")
+	}), func(time.Duration) {
+		steps = append(steps, "time")
+	}), func(any) {
+		steps = append(steps, "recover")
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.Equal(t, []string{"log", "handle", "time"}, steps)
+
+	steps = nil
+	assert.NoError(t, Publish(progressEvent{Count: -1}))
+	assert.Equal(t, []string{"log", "handle", "recover"}, steps)
+}
+
+func TestDecorators_NilHandler(t *testing.T) {
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		WithLogging[progressEvent](nil, func(progressEvent) {})
+	})
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		WithRecover[progressEvent](nil, func(any) {})
+	})
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		WithTiming[progressEvent](nil, func(time.Duration) {})
+	})
+}
@@ -0,0 +1,80 @@
+package eventbus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// ErrMaxDepthExceeded is returned by Publish when publishing an event from a
+// handler would exceed the maximum publish depth set with WithMaxPublishDepth.
+var ErrMaxDepthExceeded = errors.New("eventbus: maximum publish depth exceeded")
+
+// ErrReentrantPublish is returned by Publish under WithReentrancyGuard when an
+// event is published from a handler of an event of the same type on the same
+// goroutine.
+var ErrReentrantPublish = errors.New("eventbus: event type is already being published")
+
+var (
+	depthMu = sync.Mutex{}
+	// publishStacks holds the types of the events each goroutine is publishing,
+	// the innermost last, so its length is the publish depth of the goroutine.
+	publishStacks = make(map[uint64][]reflect.Type)
+)
+
+// enterPublish records the current goroutine is publishing an event of the type,
+// incrementing its publish depth, returning a function that removes it again. If
+// the maximum publish depth would be exceeded ErrMaxDepthExceeded is returned,
+// and under WithReentrancyGuard if the goroutine is already publishing an event
+// of the type an error wrapping ErrReentrantPublish is returned, leaving the
+// depth unchanged. The caller must hold the read lock.
+func enterPublish(eventType reflect.Type) (func(), error) {
+	if cfg.maxPublishDepth <= 0 && !cfg.reentrancyGuard {
+		return func() {}, nil
+	}
+
+	id := goroutineID()
+	depthMu.Lock()
+	defer depthMu.Unlock()
+
+	stack := publishStacks[id]
+	if cfg.maxPublishDepth > 0 && len(stack) >= cfg.maxPublishDepth {
+		return nil, ErrMaxDepthExceeded
+	}
+	if cfg.reentrancyGuard && slices.Contains(stack, eventType) {
+		return nil, fmt.Errorf("%w: %s", ErrReentrantPublish, typeName(eventType))
+	}
+	publishStacks[id] = append(stack, eventType)
+
+	return func() {
+		depthMu.Lock()
+		defer depthMu.Unlock()
+
+		stack := publishStacks[id]
+		if len(stack) <= 1 {
+			delete(publishStacks, id)
+			return
+		}
+		publishStacks[id] = stack[:len(stack)-1]
+	}, nil
+}
+
+// goroutineID returns the ID of the current goroutine, which is parsed from the
+// header of its stack trace, "goroutine 1 [running]:". Go intentionally doesn't
+// expose goroutine IDs, so this should only be used where there is no
+// alternative, such as detecting recursive publishing.
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	s := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if i := bytes.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	id, _ := strconv.ParseUint(string(s), 10, 64)
+	return id
+}
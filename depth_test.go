@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMaxPublishDepth(t *testing.T) {
+	reset()
+	Configure(WithMaxPublishDepth(10))
+
+	invoked := 0
+	SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(event progressEvent) error {
+		invoked++
+		return Publish(progressEvent{Count: event.Count + 1})
+	}))
+
+	err := Publish(progressEvent{Count: 1})
+	assert.ErrorIs(t, err, ErrMaxDepthExceeded)
+	assert.Equal(t, 10, invoked)
+
+	// Depth is tracked per goroutine and released after publishing
+	invoked = 0
+	err = Publish(progressEvent{Count: 1})
+	assert.ErrorIs(t, err, ErrMaxDepthExceeded)
+	assert.Equal(t, 10, invoked)
+	assert.Empty(t, publishStacks)
+}
+
+func TestWithReentrancyGuard(t *testing.T) {
+	reset()
+	Configure(WithReentrancyGuard())
+
+	invoked := 0
+	var nested error
+	SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(event progressEvent) error {
+		invoked++
+		nested = Publish(progressEvent{Count: event.Count + 1})
+		return Publish(orderEvent{Seq: event.Count})
+	}))
+	var orders []orderEvent
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(event orderEvent) {
+		orders = append(orders, event)
+	}))
+
+	// The nested publish of the same type is suppressed while other types are
+	// still dispatched
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.Equal(t, 1, invoked)
+	assert.ErrorIs(t, nested, ErrReentrantPublish)
+	assert.Equal(t, []orderEvent{{Seq: 1}}, orders)
+	assert.Empty(t, publishStacks)
+
+	// Publishing the type again once the first publish returned is allowed
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.Equal(t, 2, invoked)
+}
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	assert.NotZero(t, id)
+	assert.Equal(t, id, goroutineID())
+
+	other := make(chan uint64)
+	go func() {
+		other <- goroutineID()
+	}()
+	assert.NotEqual(t, id, <-other)
+}
@@ -0,0 +1,63 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// FieldChanged is published by DiffPublish for each field that differs between
+// two values of a struct type.
+type FieldChanged struct {
+	// Type is the struct type the field belongs to.
+	Type reflect.Type
+	// Field is the name of the field.
+	Field string
+	// Old is the value of the field before the change.
+	Old any
+	// New is the value of the field after the change.
+	New any
+}
+
+// DiffPublish compares the exported fields of two values of a struct type, or
+// pointers to one, and publishes a FieldChanged event with Publish for each field
+// whose value differs, in the order the fields are declared. This saves writing
+// code diffing an aggregate by hand to derive field level events, such as an
+// email change of a user. Fields are compared with reflect.DeepEqual, so a field
+// of a nested struct type is reported as a whole rather than field by field.
+// Errors returned by Publish are joined and returned, and an error is returned
+// without publishing if the values aren't structs of the same type or pointers to
+// them, or if either pointer is nil.
+func DiffPublish[T any](before, after T) error {
+	b, a := reflect.ValueOf(before), reflect.ValueOf(after)
+	if !b.IsValid() || !a.IsValid() || b.Type() != a.Type() {
+		return fmt.Errorf("eventbus: DiffPublish requires values of the same type, got %T and %T", before, after)
+	}
+	if b.Kind() == reflect.Pointer {
+		if b.IsNil() || a.IsNil() {
+			return fmt.Errorf("eventbus: DiffPublish of nil %s", b.Type())
+		}
+		b, a = b.Elem(), a.Elem()
+	}
+	if b.Kind() != reflect.Struct {
+		return fmt.Errorf("eventbus: DiffPublish requires a struct, got %s", TypeOf[T]())
+	}
+
+	var errs []error
+	structType := b.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		old, updated := b.Field(i).Interface(), a.Field(i).Interface()
+		if reflect.DeepEqual(old, updated) {
+			continue
+		}
+		err := Publish(FieldChanged{Type: structType, Field: field.Name, Old: old, New: updated})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,48 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type userProfile struct {
+	Name    string
+	Email   string
+	Tags    []string
+	Age     int
+	private string
+}
+
+func TestDiffPublish(t *testing.T) {
+	reset()
+
+	var changes []FieldChanged
+	Subscribe[FieldChanged](HandlerFunc[FieldChanged](func(event FieldChanged) {
+		changes = append(changes, event)
+	}))
+
+	before := userProfile{Name: "John Doe", Email: "jdoe@gmail.com", Tags: []string{"a"}, Age: 30, private: "x"}
+	after := userProfile{Name: "John Doe", Email: "john@doe.com", Tags: []string{"a", "b"}, Age: 30, private: "y"}
+	assert.NoError(t, DiffPublish(before, after))
+
+	profileType := reflect.TypeOf(userProfile{})
+	assert.Equal(t, []FieldChanged{
+		{Type: profileType, Field: "Email", Old: "jdoe@gmail.com", New: "john@doe.com"},
+		{Type: profileType, Field: "Tags", Old: []string{"a"}, New: []string{"a", "b"}},
+	}, changes)
+
+	// Pointers are dereferenced and equal values publish nothing
+	changes = nil
+	assert.NoError(t, DiffPublish(&after, &after))
+	assert.Empty(t, changes)
+}
+
+func TestDiffPublish_NotStruct(t *testing.T) {
+	reset()
+
+	assert.Error(t, DiffPublish(1, 2))
+	assert.Error(t, DiffPublish[*userProfile](nil, &userProfile{}))
+	assert.Error(t, DiffPublish[any](userProfile{}, nil))
+}
@@ -0,0 +1,53 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+)
+
+// doneHandler closes a channel once the underlying handler is unsubscribed.
+type doneHandler[T any] struct {
+	handler      Handler[T]
+	unsubscribed chan struct{}
+	once         sync.Once
+}
+
+func (d *doneHandler[T]) OnEvent(event T) {
+	d.handler.OnEvent(event)
+}
+
+func (d *doneHandler[T]) stop() {
+	d.once.Do(func() {
+		close(d.unsubscribed)
+	})
+}
+
+// SubscribeDone registers a handler for a given type like Subscribe but returns a
+// channel and a cancel function rather than a subscription ID, which fits
+// goroutine lifecycle patterns built around selecting on channels. Calling cancel
+// unsubscribes the handler, and the channel is closed once the handler has been
+// unsubscribed, whether by cancel or otherwise, such as by CloseHandlers or by
+// being replaced with ReplaceHandler, so other code can select on it. Cancel may
+// be called more than once.
+//
+//	unsubscribed, cancel := eventbus.SubscribeDone[UserCreated](handler)
+//	defer cancel()
+//	select {
+//	case <-unsubscribed:
+//	case <-ctx.Done():
+//	}
+func SubscribeDone[T any](handler Handler[T]) (unsubscribed <-chan struct{}, cancel func()) {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	d := &doneHandler[T]{
+		handler:      handler,
+		unsubscribed: make(chan struct{}),
+	}
+	id := subscribe(reflect.TypeOf(*new(T)), d, handlerInvoker[T](d), 2)
+	return d.unsubscribed, func() {
+		Unsubscribe[T](id)
+	}
+}
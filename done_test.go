@@ -0,0 +1,48 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeDone(t *testing.T) {
+	reset()
+	var received []progressEvent
+	unsubscribed, cancel := SubscribeDone[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event)
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	select {
+	case <-unsubscribed:
+		t.Fatal("channel closed before cancel was called")
+	default:
+	}
+
+	cancel()
+	select {
+	case <-unsubscribed:
+	default:
+		t.Fatal("channel not closed once unsubscribed")
+	}
+	assert.Error(t, Publish(progressEvent{Count: 2}))
+	assert.Equal(t, []progressEvent{{Count: 1}}, received)
+
+	// Cancelling again is a no-op
+	cancel()
+}
+
+func TestSubscribeDone_CloseHandlers(t *testing.T) {
+	reset()
+	unsubscribed, cancel := SubscribeDone[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	defer cancel()
+
+	assert.NoError(t, CloseHandlers(context.Background()))
+	select {
+	case <-unsubscribed:
+	default:
+		t.Fatal("channel not closed once unsubscribed")
+	}
+}
@@ -0,0 +1,137 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrDraining is returned when publishing an event once the bus has been drained
+// with Drain, or its type with DrainType.
+var ErrDraining = errors.New("eventbus: event type is draining")
+
+var (
+	// draining is true once the bus has been drained with Drain, which rejects
+	// publishes of every type.
+	draining bool
+
+	// drainedTypes holds the event types drained with DrainType, which no longer
+	// accept publishes.
+	drainedTypes = make(map[reflect.Type]bool)
+
+	// typeCounters holds the inflightCounter of each event type asynchronous
+	// handler invocations have been submitted for.
+	typeCounters = sync.Map{}
+
+	// typeGroups holds the sync.WaitGroup counting the synchronous publishes in
+	// progress for each event type. Handlers are invoked without holding the
+	// lock, so acquiring the write lock doesn't wait for publishes to finish.
+	typeGroups = sync.Map{}
+)
+
+// typeInflight returns the counter of the asynchronous handler invocations for
+// the event type that are queued or running.
+func typeInflight(eventType reflect.Type) *inflightCounter {
+	if counter, ok := typeCounters.Load(eventType); ok {
+		return counter.(*inflightCounter)
+	}
+	counter, _ := typeCounters.LoadOrStore(eventType, &inflightCounter{})
+	return counter.(*inflightCounter)
+}
+
+// typePublishing returns the wait group of the publishes of the event type in
+// progress. Publishes must be added while holding the read lock after checking
+// the type hasn't been drained, so none are added once DrainType waits.
+func typePublishing(eventType reflect.Type) *sync.WaitGroup {
+	if wg, ok := typeGroups.Load(eventType); ok {
+		return wg.(*sync.WaitGroup)
+	}
+	wg, _ := typeGroups.LoadOrStore(eventType, &sync.WaitGroup{})
+	return wg.(*sync.WaitGroup)
+}
+
+// checkDrained returns an error wrapping ErrDraining if the event type has been
+// drained. The caller must hold the read lock.
+func checkDrained(eventType reflect.Type) error {
+	if draining || drainedTypes[eventType] {
+		return fmt.Errorf("%w: %s", ErrDraining, typeName(eventType))
+	}
+	return nil
+}
+
+// DrainType stops accepting publishes of events of type T, which return an error
+// wrapping ErrDraining from then on, and waits for the handlers of events of type
+// T already published to return, including publishes in progress and
+// asynchronous invocations queued for the worker pool, while events of other
+// types keep flowing. This allows each subsystem to shut down gracefully during a
+// rolling shutdown. If the context is done before the handlers return the
+// context's error is returned, although the type remains drained. Events
+// buffered while the type is paused are not waited for and are still dispatched
+// by Resume. DrainType must not be called by a handler of T, which would wait
+// for itself.
+func DrainType[T any](ctx context.Context) error {
+	eventType := reflect.TypeOf(*new(T))
+
+	mu.Lock()
+	drainedTypes[eventType] = true
+	mu.Unlock()
+
+	return waitType(ctx, eventType)
+}
+
+// Drain is the first phase of a graceful shutdown of the bus. It stops accepting
+// publishes of every type, which return an error wrapping ErrDraining from then
+// on, and waits for publishes in progress and asynchronous handler
+// invocations, including those queued for the worker pool, to finish, while
+// handlers stay subscribed. Drain should be followed by CloseHandlers, which then
+// returns without waiting for any handler, unsubscribes and closes the handlers,
+// and ends draining. If the context is done before the handlers return the
+// context's error is returned, although the bus remains draining. Drain must not
+// be called by a handler, which would wait for itself.
+func Drain(ctx context.Context) error {
+	mu.Lock()
+	draining = true
+	mu.Unlock()
+
+	// No events can be published from now on, so the event types that have been
+	// published are known.
+	types := make(map[reflect.Type]struct{})
+	collect := func(eventType, _ any) bool {
+		types[eventType.(reflect.Type)] = struct{}{}
+		return true
+	}
+	typeGroups.Range(collect)
+	typeCounters.Range(collect)
+
+	for eventType := range types {
+		if err := waitType(ctx, eventType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitType waits for the publishes in progress and asynchronous
+// handler invocations of the event type, which must have been drained, to finish.
+func waitType(ctx context.Context, eventType reflect.Type) error {
+	published := make(chan struct{})
+	go func() {
+		typePublishing(eventType).Wait()
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Invocations are submitted by publishes, so they are all counted by now.
+	select {
+	case <-typeInflight(eventType).wait():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,188 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainType(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 16))
+
+	// Queue orders behind a blocked handler
+	release := make(chan struct{})
+	var orders atomic.Int32
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		<-release
+		orders.Add(1)
+	}))
+	var progress atomic.Int32
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		progress.Add(1)
+	}))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, PublishAsync(orderEvent{Seq: i}))
+	}
+
+	drained := make(chan error)
+	go func() {
+		drained <- DrainType[orderEvent](context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		return PublishAsync(orderEvent{Seq: 3}) != nil
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, Publish(orderEvent{Seq: 4}), ErrDraining)
+
+	// Other types keep flowing
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.Equal(t, int32(1), progress.Load())
+	select {
+	case <-drained:
+		t.Fatal("DrainType returned before the queued orders were handled")
+	default:
+	}
+
+	close(release)
+	assert.NoError(t, <-drained)
+	assert.Equal(t, int32(3), orders.Load())
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+}
+
+func TestDrainType_Timeout(t *testing.T) {
+	reset()
+	release := make(chan struct{})
+	defer close(release)
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		<-release
+	}))
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, DrainType[orderEvent](ctx), context.DeadlineExceeded)
+}
+
+func TestDrainType_WaitsForPublish(t *testing.T) {
+	reset()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		close(started)
+		<-release
+	}))
+
+	published := make(chan error)
+	go func() {
+		published <- Publish(orderEvent{Seq: 1})
+	}()
+	<-started
+
+	drained := make(chan error)
+	go func() {
+		drained <- DrainType[orderEvent](context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		return errors.Is(Publish(orderEvent{Seq: 2}), ErrDraining)
+	}, time.Second, time.Millisecond)
+	select {
+	case <-drained:
+		t.Fatal("DrainType returned before the publish in progress finished")
+	default:
+	}
+
+	close(release)
+	assert.NoError(t, <-published)
+	assert.NoError(t, <-drained)
+}
+
+func TestDrain(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 16))
+
+	release := make(chan struct{})
+	var orders atomic.Int32
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		<-release
+		orders.Add(1)
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, PublishAsync(orderEvent{Seq: i}))
+	}
+
+	drained := make(chan error)
+	go func() {
+		drained <- Drain(context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		return errors.Is(Publish(progressEvent{Count: 1}), ErrDraining)
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, PublishAsync(orderEvent{Seq: 3}), ErrDraining)
+	assert.ErrorIs(t, PublishBalanced(progressEvent{Count: 2}), ErrDraining)
+
+	// Queued invocations still complete
+	close(release)
+	assert.NoError(t, <-drained)
+	assert.Equal(t, int32(3), orders.Load())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, CloseHandlers(ctx))
+	err := Publish(progressEvent{Count: 3})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDraining)
+}
+
+func TestDrainType_WaitsForBlockedPublishAsync(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 1), WithBlockOnQueueFull())
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		started <- struct{}{}
+		<-release
+	}))
+	var orders atomic.Int32
+	for i := 0; i < 2; i++ {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+			orders.Add(1)
+		}))
+	}
+
+	// The worker is busy and the queue is full, so the publish blocks
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	<-started
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	published := make(chan error)
+	go func() {
+		published <- PublishAsync(orderEvent{Seq: 1})
+	}()
+	assert.Eventually(t, func() bool {
+		return QueueDepthByType(reflect.TypeOf(orderEvent{})) == 1
+	}, time.Second, time.Millisecond)
+
+	drained := make(chan error)
+	go func() {
+		drained <- DrainType[orderEvent](context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		return errors.Is(PublishAsync(orderEvent{Seq: 2}), ErrDraining)
+	}, time.Second, time.Millisecond)
+	select {
+	case <-drained:
+		t.Fatal("DrainType returned before the blocked publish finished")
+	default:
+	}
+
+	// Both handlers have returned once the type is drained
+	close(release)
+	assert.NoError(t, <-drained)
+	assert.Equal(t, int32(2), orders.Load())
+	assert.NoError(t, <-published)
+}
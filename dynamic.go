@@ -0,0 +1,12 @@
+package eventbus
+
+// PublishDynamic behaves like Publish but finds the handlers to invoke using the
+// dynamic type of the event rather than a type parameter. This allows publishing
+// events whose type is only known at runtime, such as events decoded from
+// configuration or provided by plugins. The event is delivered to the handlers
+// subscribed for its dynamic type, so an event of static type any holding a
+// UserCreated is delivered to the handlers of UserCreated. ErrNilEvent is
+// returned if the event is nil.
+func PublishDynamic(event any) error {
+	return publish(event, delivery{})
+}
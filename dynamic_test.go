@@ -0,0 +1,45 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishDynamic(t *testing.T) {
+	reset()
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	h := new(userCreatedHandler)
+	h.On("OnEvent", event).Return()
+	Subscribe[userCreatedEvent](h)
+
+	received := 0
+	Subscribe[any](HandlerFunc[any](func(any) {
+		received++
+	}))
+
+	var dynamic any = event
+	assert.NoError(t, PublishDynamic(dynamic))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+	assert.Zero(t, received)
+
+	assert.Error(t, PublishDynamic(progressEvent{Count: 1}))
+	assert.ErrorIs(t, PublishDynamic(nil), ErrNilEvent)
+}
+
+func TestPublishDynamic_Filter(t *testing.T) {
+	reset()
+	AddFilter(func(event progressEvent) (progressEvent, bool) {
+		event.Count++
+		return event, event.Count < 10
+	})
+
+	var received []progressEvent
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event)
+	}))
+
+	assert.NoError(t, PublishDynamic(progressEvent{Count: 1}))
+	assert.NoError(t, PublishDynamic(progressEvent{Count: 9}))
+	assert.Equal(t, []progressEvent{{Count: 2}}, received)
+}
@@ -0,0 +1,69 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// escalator is implemented by handlers that may need to be invoked synchronously
+// even when the event was published with PublishAsync.
+type escalator interface {
+	escalated() bool
+}
+
+// escalatingHandler invokes its primary handler until it fails threshold times
+// in a row, after which it invokes its fallback handler instead.
+type escalatingHandler[T any] struct {
+	primary   ErrorHandler[T]
+	fallback  Handler[T]
+	threshold int32
+	failures  atomic.Int32
+}
+
+func (h *escalatingHandler[T]) OnEvent(event T) error {
+	if h.escalated() {
+		h.fallback.OnEvent(event)
+		return nil
+	}
+	if err := h.primary.OnEvent(event); err != nil {
+		h.failures.Add(1)
+		return err
+	}
+	h.failures.Store(0)
+	return nil
+}
+
+func (h *escalatingHandler[T]) escalated() bool {
+	return h.failures.Load() >= h.threshold
+}
+
+// SubscribeEscalating registers a primary handler for a given type which is
+// escalated to a synchronous fallback handler once it fails repeatedly. Until
+// then events are delivered to the primary handler as normal, asynchronously when
+// published with PublishAsync. After the primary handler returns an error for
+// failures events in a row it is no longer invoked, and every event is delivered
+// to the fallback handler on the publishing goroutine instead, including events
+// published with PublishAsync, so the publisher knows the event has been handled
+// when publishing returns. This suits handlers which are handled best effort
+// while healthy but must be guaranteed once they aren't. A successful event
+// resets the count of failures. Once escalated the subscription stays escalated
+// until it is unsubscribed. The return value is a subscription ID that can be
+// used to unsubscribe both handlers.
+func SubscribeEscalating[T any](primary ErrorHandler[T], fallback Handler[T], failures int) uint64 {
+	mustNotBeNil(primary)
+	mustNotBeNil(fallback)
+	if failures < 1 {
+		failures = 1
+	}
+
+	h := &escalatingHandler[T]{
+		primary:   primary,
+		fallback:  fallback,
+		threshold: int32(failures),
+	}
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	return subscribe(reflect.TypeOf(*new(T)), h, errorHandlerInvoker[T](h), 2)
+}
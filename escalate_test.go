@@ -0,0 +1,60 @@
+package eventbus
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeEscalating(t *testing.T) {
+	reset()
+	var errs atomic.Int32
+	Configure(WithErrorCallback(func(err error) {
+		errs.Add(1)
+	}))
+
+	var primary atomic.Int32
+	var fallback []int
+	SubscribeEscalating[progressEvent](ErrorHandlerFunc[progressEvent](func(progressEvent) error {
+		primary.Add(1)
+		return errors.New("downstream unavailable")
+	}), HandlerFunc[progressEvent](func(event progressEvent) {
+		fallback = append(fallback, event.Count)
+	}), 2)
+
+	for i := 1; i <= 2; i++ {
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	assert.Eventually(t, func() bool {
+		return errs.Load() == 2
+	}, time.Second, time.Millisecond)
+	assert.Empty(t, fallback)
+
+	// The fallback handles the event before PublishAsync returns
+	assert.NoError(t, PublishAsync(progressEvent{Count: 3}))
+	assert.Equal(t, []int{3}, fallback)
+	assert.NoError(t, Publish(progressEvent{Count: 4}))
+	assert.Equal(t, []int{3, 4}, fallback)
+	assert.Equal(t, int32(2), primary.Load())
+}
+
+func TestSubscribeEscalating_ResetsFailures(t *testing.T) {
+	reset()
+	var fallback int
+	SubscribeEscalating[progressEvent](ErrorHandlerFunc[progressEvent](func(event progressEvent) error {
+		if event.Count%2 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	}), HandlerFunc[progressEvent](func(progressEvent) {
+		fallback++
+	}), 2)
+
+	for i := 0; i < 6; i++ {
+		_ = Publish(progressEvent{Count: i})
+	}
+	assert.Zero(t, fallback)
+}
@@ -1,10 +1,15 @@
 package eventbus
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Handler is a type capable of handling events published through eventbus.
@@ -18,38 +23,227 @@ func (f HandlerFunc[T]) OnEvent(event T) {
 	f(event)
 }
 
+// ErrorHandler is a type capable of handling events published through eventbus
+// that can report a failure to handle the event by returning an error.
+type ErrorHandler[T any] interface {
+	OnEvent(event T) error
+}
+
+type ErrorHandlerFunc[T any] func(event T) error
+
+func (f ErrorHandlerFunc[T]) OnEvent(event T) error {
+	return f(event)
+}
+
 type handlerEntry struct {
+	id        uint64
+	handler   interface{}
+	invoke    func(event any) error
+	invokeCtx func(ctx context.Context, event any) error
+	result    func(event any) (any, error)
+	source    string
+	key       any
+	name      string
+	suspended bool
+	priority  int
+	health    *handlerHealth
+	guard     *reentrancyGuard
+	panics    *panicTracker
+	labels    map[string]string
+	enabled   func() bool
+	// prefersAsync is true for handlers preferring to be dispatched
+	// asynchronously, see DispatchPreferrer.
+	prefersAsync bool
+	// lastInvoked is the time the handler was last invoked, or subscribed if it
+	// hasn't been invoked, in nanoseconds since the Unix epoch.
+	lastInvoked *atomic.Int64
+}
+
+type fallbackEntry struct {
 	id      uint64
-	handler interface{}
+	handler func(event any)
+}
+
+// delivery carries the per-publish state consulted while dispatching an event to
+// its handlers.
+type delivery struct {
+	// ctx is the context of an asynchronous publish, which each handler goroutine
+	// checks before invoking its handler. It is also passed to ContextHandlers.
+	ctx context.Context
+	// handlerTimeout, if greater than zero, is how long each ContextHandler has
+	// before the context passed to it is cancelled.
+	handlerTimeout time.Duration
+	// expiresAt is the time after which asynchronous deliveries are dropped. The
+	// zero value means deliveries never expire.
+	expiresAt time.Time
+	// receipt, if not nil, records the outcome of each handler invoked.
+	receipt *DeliveryReceipt
+	// remote is true for events received through a RemoteBridge.
+	remote bool
+	// replayed is true for events republished by Replay, which are not recorded
+	// in the audit log again.
+	replayed bool
+	// onResult, if not nil, is invoked with the result of each ResultHandler
+	// invoked synchronously.
+	onResult func(result any, err error)
+	// eventID and causeID are the IDs of the event and of the event that caused
+	// it when causal tracing is enabled, zero otherwise.
+	eventID uint64
+	causeID uint64
+	// budgetEnds, if not zero, is the time after which no further handlers are
+	// invoked synchronously.
+	budgetEnds time.Time
+	// selector, if not empty, selects the handlers whose labels match it.
+	selector map[string]string
+	// priority, if not nil, is the priority asynchronous invocations are queued
+	// for the worker pool with in place of the priority of their handlers.
+	priority *int
+	// batch, if not nil, counts the asynchronous invocations of the handlers and
+	// collects their errors.
+	batch *AsyncBatch
+	// balanced is true for events published with PublishBalanced, which are
+	// delivered to a single handler selected by the balance strategy.
+	balanced bool
+}
+
+// queuePriority returns the priority to queue an asynchronous invocation of a
+// handler with the given priority with.
+func (d delivery) queuePriority(handlerPriority int) int {
+	if d.priority != nil {
+		return *d.priority
+	}
+	return handlerPriority
 }
 
+// skipEntry reports whether the handler entry should not be invoked for this
+// delivery. Inactive handlers, such as suspended ones, are never invoked, and
+// handlers whose labels don't match the selector of the delivery are skipped.
+// Events received from a remote bus are never forwarded again, which prevents
+// events from looping between buses.
+func (d delivery) skipEntry(entry handlerEntry) bool {
+	if !entry.active() {
+		return true
+	}
+	if len(d.selector) > 0 && !selects(d.selector, entry.labels) {
+		return true
+	}
+	if d.remote {
+		if _, ok := entry.handler.(forwarder); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNilHandler is the value passed to panic when attempting to subscribe a nil
+// handler.
+var ErrNilHandler = errors.New("eventbus: handler must not be nil")
+
+// ErrNilEvent is returned when publishing a nil interface value, which has no
+// dynamic type to find the handlers of the event by.
+var ErrNilEvent = errors.New("eventbus: event must not be nil")
+
 var (
 	handlers            = make(map[reflect.Type][]handlerEntry)
+	fallbacks           = make([]fallbackEntry, 0)
 	mu                  = sync.RWMutex{}
 	subscriberId uint64 = 0
 )
 
 // Subscribe registers a handler for a given type. When this type is used with
 // Publish or PublishAsync, the handler will be invoked. The return values is
-// a subscription ID that can be used to unsubscribe the handler.
+// a subscription ID that can be used to unsubscribe the handler. Subscribe
+// panics with ErrNilHandler if the handler is nil.
+//
+// Handlers may publish events and subscribe or unsubscribe handlers, including
+// themselves, while they are invoked. Events being dispatched when handlers are
+// subscribed or unsubscribed are still delivered to the handlers registered when
+// their dispatch started, the change applies to events published afterwards.
 func Subscribe[T any](handler Handler[T]) uint64 {
+	mustNotBeNil(handler)
+
 	mu.Lock()
-	defer mu.Unlock()
+	defer unlockAndNotify()
+
+	return subscribe(reflect.TypeOf(*new(T)), handler, handlerInvoker(handler), 2)
+}
+
+// SubscribeErrorHandler registers an ErrorHandler for a given type. It behaves
+// the same as Subscribe except errors returned by the handler are returned from
+// Publish. The return value is a subscription ID that can be used to unsubscribe
+// the handler with Unsubscribe.
+func SubscribeErrorHandler[T any](handler ErrorHandler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
 
+	return subscribe(reflect.TypeOf(*new(T)), handler, errorHandlerInvoker(handler), 2)
+}
+
+// subscribe registers a handler for the event type and returns the subscription
+// ID. The invoke function is bound to the handler by the caller so dispatching
+// doesn't need to assert the type of the handler. The caller must hold the write
+// lock. Skip is the number of stack frames to skip when capturing caller info,
+// where 1 identifies the caller of subscribe. subscribe panics if registering the
+// handler would exceed the limit set with WithMaxHandlers, or if the schema
+// registry set with WithSchemaRegistry rejects it.
+func subscribe(eventType reflect.Type, handler interface{}, invoke func(event any) error, skip int) uint64 {
+	if cfg.maxHandlers > 0 && len(handlers[eventType]) >= cfg.maxHandlers {
+		panic(fmt.Errorf("%w %s", ErrTooManyHandlers, typeName(eventType)))
+	}
+	if err := checkSchema(eventType, handler); err != nil {
+		panic(err)
+	}
 	id := generateHandlerId()
-	eventType := reflect.TypeOf(*new(T))
-	handlers[eventType] = append(handlers[eventType], handlerEntry{
-		id:      id,
-		handler: handler,
-	})
+	if handlers[eventType] == nil && cfg.initialCapacity > 0 {
+		handlers[eventType] = make([]handlerEntry, 0, cfg.initialCapacity)
+	}
+	entry := handlerEntry{
+		id:           id,
+		handler:      handler,
+		invoke:       invoke,
+		guard:        newReentrancyGuard(handler),
+		prefersAsync: prefersAsync(handler),
+		lastInvoked:  new(atomic.Int64),
+	}
+	entry.touch()
+	if cfg.autoRemoveOnPanic > 0 {
+		entry.panics = &panicTracker{threshold: int32(cfg.autoRemoveOnPanic)}
+	}
+	if cfg.quarantineThreshold > 0 {
+		entry.health = newHandlerHealth(cfg.quarantineThreshold, cfg.quarantineStrikes)
+	}
+	if cfg.captureCallerInfo {
+		if _, file, line, ok := runtime.Caller(skip); ok {
+			entry.source = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+		}
+	}
+	handlers[eventType] = append(handlers[eventType], entry)
+	handlers[eventType] = placeByPriority(handlers[eventType], len(handlers[eventType])-1)
+	recordSubscribe()
+	queueLifecycle(eventType, SubscriptionAdded{Type: eventType, ID: id})
 	return id
 }
 
+// entryByID returns the handler entry for the event type with the subscription
+// ID, or nil if there is none. The caller must hold the write lock to modify the
+// entry.
+func entryByID(eventType reflect.Type, id uint64) *handlerEntry {
+	entries := handlers[eventType]
+	for i := range entries {
+		if entries[i].id == id {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
 // Unsubscribe removes a handler with the given subscription ID for the specified
 // type. If the handler is not found, it returns false.
 func Unsubscribe[T any](subscriptionID uint64) bool {
 	mu.Lock()
-	defer mu.Unlock()
+	defer unlockAndNotify()
 
 	eventType := reflect.TypeOf(*new(T))
 	handler, ok := handlers[eventType]
@@ -59,7 +253,48 @@ func Unsubscribe[T any](subscriptionID uint64) bool {
 
 	for i, h := range handler {
 		if h.id == subscriptionID {
-			handlers[eventType] = append(handler[:i], handler[i+1:]...)
+			// The handlers are copied rather than modified in place since a
+			// dispatch in progress may be iterating over them.
+			handlers[eventType] = append(handler[:i:i], handler[i+1:]...)
+			release(eventType, h)
+			return true
+		}
+	}
+	return false
+}
+
+// SubscribeFallback registers a fallback handler that is invoked for any event
+// that has no type-specific handlers registered. Unlike regular handlers which
+// are bound to a type, fallback handlers receive the event as any and are
+// expected to type switch or inspect the event as needed. When multiple fallback
+// handlers are registered they are invoked in the order they were registered.
+// The return value is a subscription ID that can be used to unsubscribe the
+// fallback handler with UnsubscribeFallback.
+func SubscribeFallback(handler func(event any)) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := generateHandlerId()
+	fallbacks = append(fallbacks, fallbackEntry{
+		id:      id,
+		handler: handler,
+	})
+	recordSubscribe()
+	return id
+}
+
+// UnsubscribeFallback removes a fallback handler with the given subscription ID.
+// If the fallback handler is not found, it returns false.
+func UnsubscribeFallback(subscriptionID uint64) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, f := range fallbacks {
+		if f.id == subscriptionID {
+			fallbacks = append(fallbacks[:i:i], fallbacks[i+1:]...)
+			recordUnsubscribe()
 			return true
 		}
 	}
@@ -67,71 +302,460 @@ func Unsubscribe[T any](subscriptionID uint64) bool {
 }
 
 // Publish sends an event to all handlers registered for the event type. If no
-// handlers are registered or the handler is not the correct type an error is
-// returned. All handlers for the event type will be invoked in the order they
-// were registered.
+// handlers are registered for the event type the fallback handlers are invoked
+// instead. If there are no handlers or fallback handlers an error is returned.
+// Handlers with a higher priority, see SubscribePriority, are invoked first, and
+// handlers of equal priority are invoked in the order they were registered unless
+// they have been rearranged with Reorder. Errors returned by ErrorHandlers do not
+// stop the remaining handlers from being invoked, and are joined together and
+// returned once all handlers have been invoked.
 func Publish[T any](event T) error {
+	return publish(event, delivery{})
+}
+
+// publish is the implementation of the Publish variants.
+func publish[T any](event T, d delivery) error {
+	eventType := reflect.TypeOf(event)
+	if eventType == nil {
+		return nilEventError[T]()
+	}
+	if err := waitRateLimit(eventType); err != nil {
+		return err
+	}
+
 	mu.RLock()
 	defer mu.RUnlock()
 
-	eventType := reflect.TypeOf(event)
-	handler, ok := handlers[eventType]
+	if err := checkDrained(eventType); err != nil {
+		return err
+	}
+	publishing := typePublishing(eventType)
+	publishing.Add(1)
+	defer publishing.Done()
+	if skipZeroValue(event) {
+		return nil
+	}
+
+	event, ok := applyFilters(eventType, event)
 	if !ok {
-		return fmt.Errorf("no handler for event %T", event)
+		return nil
 	}
 
-	for _, h := range handler {
-		eventHandler, ok := h.handler.(Handler[T])
-		if !ok {
-			return fmt.Errorf("handler is not of type Handler[%T]", event)
+	exit, err := enterPublish(eventType)
+	if err != nil {
+		return err
+	}
+	defer exit()
+
+	if err := appendEvent(eventType, event, d); err != nil {
+		return err
+	}
+	d = d.traced().inheriting()
+	recordAudit(eventType, event, d)
+	recordPublished(eventType)
+	retainLatest(eventType, event)
+	if buffered, err := bufferIfPaused(eventType, event, false, d); buffered {
+		return err
+	}
+	defer enterCause(d.eventID)()
+	return dispatch(eventType, event, d)
+}
+
+// dispatch invokes the handlers registered for the event type synchronously, or
+// a single one of them for events published with PublishBalanced. The handlers
+// are read before the raw handlers are invoked, so handlers subscribed while the
+// event is dispatched don't receive it. The caller must hold the read lock.
+func dispatch[T any](eventType reflect.Type, event T, d delivery) error {
+	d, release := d.sequenced(eventType)
+	defer release()
+	handler, fallback := handlers[eventType], fallbacks
+	if d.balanced {
+		return d.dispatchBalanced(eventType, handler, event)
+	}
+	dispatchRaw(eventType, event)
+
+	if len(handler) == 0 {
+		if len(fallback) == 0 {
+			return fmt.Errorf("no handler for event %s", typeName(eventType))
+		}
+		for _, f := range fallback {
+			unlocked(func() {
+				f.handler(event)
+			})
+			d.receipt.ack(f.id)
 		}
-		eventHandler.OnEvent(event)
+		return nil
 	}
 
-	return nil
+	var errs []error
+	var ran []uint64
+	var boxed any = event
+	for i, h := range handler {
+		if d.skipEntry(h) {
+			continue
+		}
+		if !d.budgetEnds.IsZero() {
+			if now().After(d.budgetEnds) {
+				errs = append(errs, budgetExceeded(ran, handler[i:], d))
+				break
+			}
+			ran = append(ran, h.id)
+		}
+		var err error
+		// ResultHandlers whose results are collected are invoked synchronously
+		// whatever their preference, so their results are part of the publish.
+		if h.prefersAsync && (d.onResult == nil || h.result == nil) {
+			err = submitEntry(eventType, typeName(eventType), h, event, d)
+		} else {
+			err = d.invoke(eventType, h, boxed)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			d.receipt.fail(h.id, err)
+			continue
+		}
+		d.receipt.ack(h.id)
+	}
+
+	return errors.Join(errs...)
+}
+
+// invoke invokes the handler of the entry synchronously. The caller must hold
+// the read lock.
+func (d delivery) invoke(eventType reflect.Type, entry handlerEntry, event any) (err error) {
+	// The metrics are recorded after a recovered panic has set err, so the
+	// collector is deferred first.
+	if cfg.metrics != nil {
+		defer observeHandler(cfg.metrics, typeName(eventType), time.Now(), &err)
+	}
+	if entry.panics != nil {
+		defer entry.panics.observe(eventType, entry.id, cfg.errorCallback, &err)
+	}
+	if cfg.panicEvents || entry.panics != nil {
+		defer recoverPanic(eventType, entry.id, true, &err)
+	}
+	if entry.guard != nil {
+		entry.guard.enter(typeName(eventType), entry.id, unlockedCallback(cfg.errorCallback))
+		defer entry.guard.exit()
+	}
+	entry.touch()
+	if cfg.priorityInheritance {
+		defer enterPriority(d.queuePriority(entry.priority))()
+	}
+
+	switch {
+	case d.onResult != nil && entry.result != nil:
+		var result any
+		unlocked(func() {
+			result, err = entry.result(event)
+		})
+		d.onResult(result, err)
+		return err
+	case entry.invokeCtx != nil:
+		unlocked(func() {
+			err = d.invokeContext(entry, event)
+		})
+		return err
+	case entry.health != nil:
+		return entry.health.invoke(eventType, entry, event)
+	default:
+		unlocked(func() {
+			err = entry.invoke(event)
+		})
+		return err
+	}
+}
+
+// unlocked calls fn with the read lock released, so the handler fn invokes can
+// publish events and subscribe or unsubscribe handlers, which would deadlock
+// while the lock is held. The caller must hold the read lock, which is acquired
+// again before unlocked returns, even if fn panics. Anything guarded by the lock
+// may have been changed in the meantime, which is why changes to the handlers
+// of an event type are copied rather than made in place.
+func unlocked(fn func()) {
+	mu.RUnlock()
+	defer mu.RLock()
+	fn()
+}
+
+// unlockedCallback returns a function calling the error callback with the read
+// lock released, for reporting errors while the read lock is held, or nil if
+// there is no callback.
+func unlockedCallback(callback func(err error)) func(err error) {
+	if callback == nil {
+		return nil
+	}
+	return func(err error) {
+		unlocked(func() {
+			callback(err)
+		})
+	}
 }
 
 // MustPublish behaves like Publish sending an event to all handlers registered for
-// the event type but panics on error.
+// the event type but panics on error. If an error callback has been configured
+// with WithErrorCallback the error is passed to the callback instead of panicking,
+// unless strict mode has been enabled with WithStrictMode in which case
+// MustPublish always panics.
 func MustPublish[T any](event T) {
 	if err := Publish(event); err != nil {
-		panic(err)
+		handleMustError(err)
 	}
 }
 
 // PublishAsync sends an event to all handlers registered for the event type. If no
-// handlers are registered or the handler is not the correct type an error is
-// returned. All handlers for the event type will be invoked asynchronously in new
-// goroutines.
+// handlers are registered for the event type the fallback handlers are invoked
+// instead. If there are no handlers or fallback handlers an error is returned.
+// All handlers for the event type will be invoked asynchronously in new
+// goroutines, or on the worker pool when one is configured with WithWorkerPool.
+//
+// Handlers are started in the same order Publish invokes them, but as each runs
+// concurrently there is no guarantee of the order they run in or complete in,
+// for a single event or between events. WithConsistentOrdering runs the handlers
+// of each event one after another on a single goroutine instead, in the same
+// order as Publish.
 func PublishAsync[T any](event T) error {
+	return PublishAsyncCtx(context.Background(), event)
+}
+
+// PublishAsyncCtx behaves like PublishAsync but honors cancellation of the provided
+// context. If the context is already done the event is not dispatched and the
+// context error is returned. Each handler goroutine checks the context again
+// before invoking the handler, skipping the event if the context was cancelled
+// in the meantime. This prevents stale events from being processed after a
+// shutdown signal.
+func PublishAsyncCtx[T any](ctx context.Context, event T) error {
+	return publishAsync(event, delivery{ctx: ctx})
+}
+
+// publishAsync is the implementation of the PublishAsync variants.
+func publishAsync[T any](event T, d delivery) error {
+	if err := d.ctx.Err(); err != nil {
+		return err
+	}
+
+	eventType := reflect.TypeOf(event)
+	if eventType == nil {
+		return nilEventError[T]()
+	}
+	if err := waitRateLimit(eventType); err != nil {
+		return err
+	}
+
 	mu.RLock()
 	defer mu.RUnlock()
 
-	eventType := reflect.TypeOf(event)
-	handler, ok := handlers[eventType]
+	if err := checkDrained(eventType); err != nil {
+		return err
+	}
+	// The read lock may be released while the handlers are submitted, so the
+	// publish is counted until they all have been.
+	publishing := typePublishing(eventType)
+	publishing.Add(1)
+	defer publishing.Done()
+	if skipZeroValue(event) {
+		return nil
+	}
+
+	event, ok := applyFilters(eventType, event)
 	if !ok {
-		return fmt.Errorf("no handler for event %T", event)
+		return nil
+	}
+
+	if err := appendEvent(eventType, event, d); err != nil {
+		return err
+	}
+	d = d.traced().inheriting()
+	recordAudit(eventType, event, d)
+	recordPublished(eventType)
+	retainLatest(eventType, event)
+	if buffered, err := bufferIfPaused(eventType, event, true, d); buffered {
+		return err
+	}
+	return dispatchAsync(eventType, event, d)
+}
+
+// dispatchAsync invokes the handlers registered for the event type each in a new
+// goroutine. Like dispatch it reads the handlers before the raw handlers are
+// invoked. The caller must hold the read lock.
+func dispatchAsync[T any](eventType reflect.Type, event T, d delivery) error {
+	d, release := d.sequenced(eventType)
+	defer release()
+	handler, fallback := handlers[eventType], fallbacks
+	dispatchRawAsync(eventType, event, d)
+
+	callback, name := cfg.errorCallback, typeName(eventType)
+	if len(handler) == 0 {
+		if len(fallback) == 0 {
+			return fmt.Errorf("no handler for event %s", typeName(eventType))
+		}
+		var errs []error
+		for _, f := range fallback {
+			fn, event := f.handler, copyAsync(event)
+			err := d.submitAsync(eventType, 0, func() {
+				if d.expired(name, callback) {
+					return
+				}
+				fn(event)
+			}, nil)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: fallback subscription %d", err, f.id))
+			}
+		}
+		return errors.Join(errs...)
 	}
 
+	if cfg.consistentOrdering {
+		return dispatchOrdered(eventType, handler, event, d)
+	}
+
+	var errs []error
 	for _, h := range handler {
-		eventHandler, ok := h.handler.(Handler[T])
-		if !ok {
-			return fmt.Errorf("handler is not of type Handler[%T]", event)
+		if d.skipEntry(h) {
+			continue
+		}
+		if e, ok := h.handler.(escalator); ok && e.escalated() {
+			var err error
+			unlocked(func() {
+				err = h.invoke(event)
+			})
+			if err != nil {
+				d.reportAsync(unlockedCallback(callback), err)
+			}
+			continue
+		}
+		if err := submitEntry(eventType, name, h, event, d); err != nil {
+			errs = append(errs, err)
 		}
-		go eventHandler.OnEvent(event)
 	}
 
+	return errors.Join(errs...)
+}
+
+// submitEntry invokes the handler of the entry with the event asynchronously,
+// reporting its error with reportAsync. The caller must hold the read lock.
+func submitEntry[T any](eventType reflect.Type, name string, h handlerEntry, event T, d delivery) error {
+	callback := cfg.errorCallback
+	invoke, event := d.asyncInvoker(eventType, name, h), copyAsync(event)
+	err := d.submitAsync(eventType, h.priority, func() {
+		if d.expired(name, callback) {
+			return
+		}
+		if err := invoke(event); err != nil {
+			d.reportAsync(callback, err)
+		}
+	}, spillable(eventType, h.id, event))
+	if err != nil {
+		return fmt.Errorf("%w: subscription %d", err, h.id)
+	}
 	return nil
 }
 
+// asyncInvoker returns the function invoking the handler of the entry from an
+// asynchronous task. The caller must hold the read lock.
+func (d delivery) asyncInvoker(eventType reflect.Type, name string, entry handlerEntry) func(event any) error {
+	invoke := entry.invoke
+	if entry.invokeCtx != nil {
+		invoke = func(event any) error {
+			return d.invokeContext(entry, event)
+		}
+	}
+	if entry.guard != nil {
+		invoke = entry.guard.guarding(name, entry.id, cfg.errorCallback, invoke)
+	}
+	invoke = entry.touching(invoke)
+	if d.eventID != 0 {
+		invoke = d.causing(invoke)
+	}
+	if cfg.priorityInheritance {
+		invoke = d.prioritizing(entry.priority, invoke)
+	}
+	if cfg.panicEvents || entry.panics != nil {
+		invoke = recovering(eventType, entry.id, invoke)
+	}
+	if entry.panics != nil {
+		invoke = entry.panics.observing(eventType, entry.id, cfg.errorCallback, invoke)
+	}
+	if cfg.metrics != nil {
+		invoke = observed(cfg.metrics, name, invoke)
+	}
+	return invoke
+}
+
 // MustPublishAsync behaves like PublishAsync sending an event to all handlers
-// registered for the event type asynchronously but panics on error.
+// registered for the event type asynchronously but panics on error. The error
+// callback and strict mode are honored the same as MustPublish.
 func MustPublishAsync[T any](event T) {
 	if err := PublishAsync(event); err != nil {
-		panic(err)
+		handleMustError(err)
+	}
+}
+
+// handleMustError routes an error from one of the Must variants to the error
+// callback if one is configured and strict mode is disabled, otherwise it panics.
+func handleMustError(err error) {
+	mu.RLock()
+	callback, strict := cfg.errorCallback, cfg.strict
+	mu.RUnlock()
+
+	if callback != nil && !strict {
+		callback(err)
+		return
+	}
+	panic(err)
+}
+
+// handlerInvoker binds a Handler to a function that can be invoked with an event
+// of any type. The event must be of type T.
+func handlerInvoker[T any](handler Handler[T]) func(event any) error {
+	return func(event any) error {
+		handler.OnEvent(event.(T))
+		return nil
+	}
+}
+
+// errorHandlerInvoker binds an ErrorHandler to a function that can be invoked
+// with an event of any type. The event must be of type T.
+func errorHandlerInvoker[T any](handler ErrorHandler[T]) func(event any) error {
+	return func(event any) error {
+		return handler.OnEvent(event.(T))
+	}
+}
+
+// mustNotBeNil panics with ErrNilHandler if the handler is nil, including typed
+// nil values such as a nil pointer or HandlerFunc. This surfaces the mistake when
+// the handler is subscribed rather than when an event is later published.
+func mustNotBeNil(handler any) {
+	if handler == nil {
+		panic(ErrNilHandler)
+	}
+	v := reflect.ValueOf(handler)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		if v.IsNil() {
+			panic(ErrNilHandler)
+		}
+	}
+}
+
+// release records the removal of a handler entry and stops the handler if it owns
+// resources that need to be released. The caller must hold the write lock.
+func release(eventType reflect.Type, entry handlerEntry) {
+	recordUnsubscribe()
+	queueLifecycle(eventType, SubscriptionRemoved{Type: eventType, ID: entry.id})
+	if s, ok := entry.handler.(stopper); ok {
+		s.stop()
+	}
+	if entry.health != nil {
+		entry.health.stop()
 	}
 }
 
 func generateHandlerId() uint64 {
 	return atomic.AddUint64(&subscriberId, 1)
 }
+
+// nilEventError returns the error for publishing a nil interface value of type T.
+func nilEventError[T any]() error {
+	return fmt.Errorf("%w: nil %s", ErrNilEvent, ShortTypeName(TypeOf[T]()))
+}
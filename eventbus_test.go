@@ -1,6 +1,8 @@
 package eventbus
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"sync"
 	"testing"
@@ -63,8 +65,271 @@ func TestPublishAsync(t *testing.T) {
 	h.AssertNumberOfCalls(t, "OnEvent", 1)
 }
 
+func TestPublishAsyncCtx(t *testing.T) {
+	reset()
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}).Return()
+	Subscribe[userCreatedEvent](h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := PublishAsyncCtx(ctx, userCreatedEvent{Name: "Jane Doe", Email: "janed@gmail.com"})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = PublishAsyncCtx(context.Background(), userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.NoError(t, err)
+
+	// Since its invoked async need to wait for it to run
+	time.Sleep(1 * time.Second)
+
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestMustPublish(t *testing.T) {
+	reset()
+	assert.Panics(t, func() {
+		MustPublish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	})
+}
+
+func TestMustPublish_ErrorCallback(t *testing.T) {
+	reset()
+	var errs []error
+	Configure(WithErrorCallback(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	assert.NotPanics(t, func() {
+		MustPublish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+		MustPublishAsync(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	})
+	assert.Len(t, errs, 2)
+}
+
+func TestMustPublish_StrictMode(t *testing.T) {
+	reset()
+	invoked := false
+	Configure(WithErrorCallback(func(err error) {
+		invoked = true
+	}), WithStrictMode())
+
+	assert.Panics(t, func() {
+		MustPublish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	})
+	assert.False(t, invoked)
+}
+
+func TestPublish_ErrorHandler(t *testing.T) {
+	reset()
+	errFailed := errors.New("failed to handle event")
+	invoked := 0
+	SubscribeErrorHandler[userCreatedEvent](ErrorHandlerFunc[userCreatedEvent](func(event userCreatedEvent) error {
+		invoked++
+		return errFailed
+	}))
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		invoked++
+	}))
+
+	err := Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.ErrorIs(t, err, errFailed)
+	assert.Equal(t, 2, invoked)
+}
+
+func TestSubscribeFallback(t *testing.T) {
+	reset()
+	var received []any
+	SubscribeFallback(func(event any) {
+		received = append(received, event)
+	})
+	SubscribeFallback(func(event any) {
+		received = append(received, "second")
+	})
+
+	err := Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}, "second"}, received)
+}
+
+func TestSubscribeFallback_NotInvokedWhenHandlerExists(t *testing.T) {
+	reset()
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}).Return()
+
+	invoked := false
+	SubscribeFallback(func(event any) {
+		invoked = true
+	})
+	Subscribe[userCreatedEvent](h)
+
+	err := Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.NoError(t, err)
+	assert.False(t, invoked)
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestUnsubscribeFallback(t *testing.T) {
+	reset()
+	id := SubscribeFallback(func(event any) {})
+	assert.True(t, UnsubscribeFallback(id))
+	assert.False(t, UnsubscribeFallback(id))
+
+	err := Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.Error(t, err)
+}
+
+func TestSubscribe_NilHandler(t *testing.T) {
+	reset()
+	var nilFunc HandlerFunc[userCreatedEvent]
+	var nilPointer *userCreatedHandler
+
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		Subscribe[userCreatedEvent](nil)
+	})
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		Subscribe[userCreatedEvent](nilFunc)
+	})
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		Subscribe[userCreatedEvent](nilPointer)
+	})
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		SubscribeFallback(nil)
+	})
+	assert.Equal(t, SubscriptionStats{}, Stats())
+}
+
+func TestPublish_NilEvent(t *testing.T) {
+	reset()
+	invoked := false
+	SubscribeFallback(func(event any) {
+		invoked = true
+	})
+
+	err := Publish[error](nil)
+	assert.ErrorIs(t, err, ErrNilEvent)
+	assert.EqualError(t, err, "eventbus: event must not be nil: nil error")
+	assert.ErrorIs(t, PublishAsync[error](nil), ErrNilEvent)
+	assert.ErrorIs(t, PublishBalanced[error](nil), ErrNilEvent)
+	assert.False(t, invoked)
+}
+
+func TestSubscribe_FromHandler(t *testing.T) {
+	reset()
+
+	var received []int
+	var id uint64
+	id = Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+			received = append(received, event.Count)
+		}))
+		assert.True(t, Unsubscribe[progressEvent](id))
+	}))
+
+	done := make(chan error)
+	go func() {
+		done <- Publish(progressEvent{Count: 1})
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked subscribing from a handler")
+	}
+
+	// The handler subscribed during dispatch only receives later events
+	assert.Empty(t, received)
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.Equal(t, []int{2}, received)
+	assert.Len(t, handlers[reflect.TypeOf(progressEvent{})], 1)
+}
+
+func TestPublish_FromHandlerWhileSubscribing(t *testing.T) {
+	reset()
+
+	// A writer waiting for the lock mustn't block publishing from a handler
+	subscribing := make(chan struct{})
+	subscribed := make(chan struct{})
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		close(subscribing)
+		<-subscribed
+		assert.NoError(t, Publish(progressEvent{Count: 1}))
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	done := make(chan error)
+	go func() {
+		done <- Publish(orderEvent{Seq: 1})
+	}()
+	<-subscribing
+	writer := make(chan struct{})
+	go func() {
+		defer close(writer)
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(subscribed)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked publishing from a handler")
+	}
+	<-writer
+}
+
+func BenchmarkPublish(b *testing.B) {
+	reset()
+	for i := 0; i < 10; i++ {
+		Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {}))
+	}
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Publish(event)
+	}
+}
+
 func reset() {
 	handlers = make(map[reflect.Type][]handlerEntry)
+	fallbacks = make([]fallbackEntry, 0)
+	rawHandlers = make([]rawEntry, 0)
+	sequence.Store(0)
+	eventIDs.Store(0)
+	draining = false
+	drainedTypes = make(map[reflect.Type]bool)
+	versions = sync.Map{}
+	clock.Store(nil)
 	mu = sync.RWMutex{}
 	subscriberId = 0
+	subscribeCount = 0
+	unsubscribeCount = 0
+	activeCount = 0
+	cfg = config{}
+	balanceCursors = sync.Map{}
+	pauses = make(map[reflect.Type]*pauseState)
+	expiredCount = 0
+	latest = sync.Map{}
+	jsonNames = make(map[reflect.Type]string)
+	jsonTypes = make(map[string]func(codec Codec, payload []byte) error)
+	responders = make(map[responderKey]responderEntry)
+	rateLimiters = sync.Map{}
+	publishStacks = make(map[uint64][]reflect.Type)
+	handlerFactories = make(map[string]handlerFactory)
+	priorities = make(map[uint64][]int)
+	causes = make(map[uint64][]uint64)
+	filters = make(map[reflect.Type][]filterEntry)
+	transforms = make(map[reflect.Type][]transformEntry)
+	pendingLifecycle = nil
+	seenKeys = seenSet{keys: make(map[string]time.Time)}
+	inflight = &inflightCounter{}
+	auditLog = auditBuffer{}
+	if pool != nil {
+		pool.stop()
+		pool = nil
+	}
+	configureBulkheads()
+	stopPeriodics()
 }
@@ -0,0 +1,101 @@
+// Package eventbusprom implements an eventbus.MetricsCollector which records
+// metrics with Prometheus. It is a separate module so eventbus itself doesn't
+// depend on Prometheus.
+//
+//	collector, err := eventbusprom.NewCollector(prometheus.DefaultRegisterer, "myapp")
+//	if err != nil {
+//		// handle error
+//	}
+//	eventbus.Configure(eventbus.WithMetrics(collector))
+package eventbusprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/jkratz55/eventbus-go"
+)
+
+// Collector is an eventbus.MetricsCollector recording the following metrics,
+// each labeled with the event type:
+//
+//   - eventbus_events_published_total counts the events published.
+//   - eventbus_handler_errors_total counts the errors returned by handlers.
+//   - eventbus_handler_duration_seconds observes how long handlers take.
+//   - eventbus_publish_block_duration_seconds observes how long publishers are
+//     blocked waiting for room in the queue under eventbus.WithBlockOnQueueFull.
+//
+// The metric names are prefixed with the namespace given to NewCollector.
+type Collector struct {
+	published *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	blocked   *prometheus.HistogramVec
+}
+
+var (
+	_ eventbus.MetricsCollector     = (*Collector)(nil)
+	_ eventbus.PublishBlockObserver = (*Collector)(nil)
+)
+
+// NewCollector creates a Collector and registers its metrics with the registerer.
+// If namespace is not empty it is prepended to the names of the metrics. An error
+// is returned if the metrics can't be registered, such as because metrics with
+// the same names are already registered.
+func NewCollector(reg prometheus.Registerer, namespace string) (*Collector, error) {
+	c := &Collector{
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "eventbus",
+			Name:      "events_published_total",
+			Help:      "The total number of events published.",
+		}, []string{"event_type"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "eventbus",
+			Name:      "handler_errors_total",
+			Help:      "The total number of errors returned by handlers.",
+		}, []string{"event_type"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "eventbus",
+			Name:      "handler_duration_seconds",
+			Help:      "How long handlers take to handle events.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"event_type"}),
+		blocked: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "eventbus",
+			Name:      "publish_block_duration_seconds",
+			Help:      "How long publishers are blocked waiting for room in the async queue.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"event_type"}),
+	}
+
+	for _, collector := range []prometheus.Collector{c.published, c.errors, c.duration, c.blocked} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// EventPublished increments the count of events published of the type.
+func (c *Collector) EventPublished(eventType string) {
+	c.published.WithLabelValues(eventType).Inc()
+}
+
+// HandlerCompleted observes the duration of the handler and, if it returned an
+// error, increments the count of handler errors of the type.
+func (c *Collector) HandlerCompleted(eventType string, elapsed time.Duration, err error) {
+	c.duration.WithLabelValues(eventType).Observe(elapsed.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(eventType).Inc()
+	}
+}
+
+// PublishBlocked observes how long a publisher of the type was blocked.
+func (c *Collector) PublishBlocked(eventType string, blocked time.Duration) {
+	c.blocked.WithLabelValues(eventType).Observe(blocked.Seconds())
+}
@@ -0,0 +1,62 @@
+package eventbusprom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jkratz55/eventbus-go"
+)
+
+type orderPlaced struct {
+	ID int
+}
+
+func TestCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	collector, err := NewCollector(reg, "test")
+	require.NoError(t, err)
+	eventbus.Configure(eventbus.WithMetrics(collector))
+	defer eventbus.Configure(eventbus.WithMetrics(nil))
+
+	handlerErr := errors.New("failed")
+	id := eventbus.SubscribeErrorHandler[orderPlaced](eventbus.ErrorHandlerFunc[orderPlaced](func(event orderPlaced) error {
+		if event.ID < 0 {
+			return handlerErr
+		}
+		return nil
+	}))
+	defer eventbus.Unsubscribe[orderPlaced](id)
+
+	assert.NoError(t, eventbus.Publish(orderPlaced{ID: 1}))
+	assert.ErrorIs(t, eventbus.Publish(orderPlaced{ID: -1}), handlerErr)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.published.WithLabelValues("orderPlaced")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.errors.WithLabelValues("orderPlaced")))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.duration, "test_eventbus_handler_duration_seconds"))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.ElementsMatch(t, []string{
+		"test_eventbus_events_published_total",
+		"test_eventbus_handler_errors_total",
+		"test_eventbus_handler_duration_seconds",
+	}, names)
+}
+
+func TestNewCollector_AlreadyRegistered(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	_, err := NewCollector(reg, "test")
+	require.NoError(t, err)
+
+	_, err = NewCollector(reg, "test")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package eventbustest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jkratz55/eventbus-go"
+)
+
+// FakeClock is an eventbus.Clock whose time only moves when it is advanced with
+// Advance, firing the timers that are due. Configured with eventbus.WithClock it
+// allows the time based features of the bus, such as coalescing windows and rate
+// limits, to be tested deterministically without sleeping.
+//
+//	clock := eventbustest.NewFakeClock(time.Now())
+//	eventbus.Configure(eventbus.WithClock(clock))
+//	defer eventbus.Configure(eventbus.WithClock(nil))
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns a channel the time is sent on once the clock has been advanced
+// by d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer returns a timer which fires once the clock has been advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) eventbus.Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the time of the clock forward by d, firing the timers that are
+// due in the order of their deadlines.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []*fakeTimer
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.deadline.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		due = append(due, t)
+	}
+	c.timers = pending
+	now := c.now
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].deadline.Before(due[j].deadline)
+	})
+	for _, t := range due {
+		t.fire(now)
+	}
+}
+
+// Timers returns the number of timers that haven't fired or been stopped.
+func (c *FakeClock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+// WaitForTimers blocks until at least n timers are waiting to fire, which allows
+// a test to wait for code running on another goroutine to start a timer before
+// advancing the clock.
+func (c *FakeClock) WaitForTimers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// remove removes the timer from the timers waiting to fire, returning whether it
+// was waiting. The caller must hold the lock of the clock.
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	for i, waiting := range c.timers {
+		if waiting == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	active := c.remove(t)
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		now := c.now
+		c.mu.Unlock()
+		t.fire(now)
+		return active
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	c.mu.Unlock()
+	return active
+}
+
+// fire sends the time on the channel of the timer, dropping it if the channel
+// already holds a time, like time.Timer.
+func (t *fakeTimer) fire(now time.Time) {
+	select {
+	case t.c <- now:
+	default:
+	}
+}
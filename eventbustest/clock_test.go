@@ -0,0 +1,145 @@
+package eventbustest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jkratz55/eventbus-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type priceChanged struct {
+	Delta int
+}
+
+type apiCalled struct{}
+
+type jobQueued struct {
+	ID int
+}
+
+func TestFakeClock_Coalesced(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	eventbus.Configure(eventbus.WithClock(clock))
+	defer eventbus.Configure(eventbus.WithClock(nil))
+
+	merged := make(chan priceChanged, 1)
+	id := eventbus.SubscribeCoalesced[priceChanged](eventbus.HandlerFunc[priceChanged](func(event priceChanged) {
+		merged <- event
+	}), func(a, b priceChanged) priceChanged {
+		return priceChanged{Delta: a.Delta + b.Delta}
+	}, time.Second)
+	defer eventbus.Unsubscribe[priceChanged](id)
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, eventbus.Publish(priceChanged{Delta: i}))
+	}
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-merged:
+		t.Fatal("coalesced event delivered before the window elapsed")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case event := <-merged:
+		assert.Equal(t, priceChanged{Delta: 6}, event)
+	case <-time.After(time.Second):
+		t.Fatal("coalesced event not delivered once the window elapsed")
+	}
+}
+
+func TestFakeClock_RateLimit(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	eventbus.Configure(eventbus.WithClock(clock), eventbus.WithBlockOnRateLimit())
+	defer eventbus.Configure(eventbus.WithClock(nil))
+	eventbus.SetRateLimit[apiCalled](1)
+	defer eventbus.SetRateLimit[apiCalled](0)
+
+	id := eventbus.Subscribe[apiCalled](eventbus.HandlerFunc[apiCalled](func(apiCalled) {}))
+	defer eventbus.Unsubscribe[apiCalled](id)
+
+	assert.NoError(t, eventbus.Publish(apiCalled{}))
+	published := make(chan error)
+	go func() {
+		published <- eventbus.Publish(apiCalled{})
+	}()
+
+	// The second publish waits for a token until the clock is advanced
+	clock.WaitForTimers(1)
+	select {
+	case <-published:
+		t.Fatal("publish exceeding the rate limit didn't wait")
+	default:
+	}
+	clock.Advance(time.Second)
+	assert.NoError(t, <-published)
+}
+
+func TestFakeClock_PublishAsyncTTL(t *testing.T) {
+	var (
+		lock sync.Mutex
+		errs []error
+	)
+	clock := NewFakeClock(time.Now())
+	eventbus.Configure(eventbus.WithClock(clock), eventbus.WithWorkerPool(1, 4), eventbus.WithErrorCallback(func(err error) {
+		lock.Lock()
+		defer lock.Unlock()
+		errs = append(errs, err)
+	}))
+	defer eventbus.Configure(eventbus.WithClock(nil), eventbus.WithWorkerPool(0, 0), eventbus.WithErrorCallback(nil))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handled := make(chan int, 3)
+	id := eventbus.Subscribe[jobQueued](eventbus.HandlerFunc[jobQueued](func(event jobQueued) {
+		if event.ID == 1 {
+			close(started)
+			<-release
+		}
+		handled <- event.ID
+	}))
+	defer eventbus.Unsubscribe[jobQueued](id)
+	expired := eventbus.ExpiredCount()
+
+	// The first event holds up the only worker while the others queue behind it,
+	// and the second outlives its TTL in the queue
+	assert.NoError(t, eventbus.PublishAsyncTTL(jobQueued{ID: 1}, time.Minute))
+	<-started
+	assert.NoError(t, eventbus.PublishAsyncTTL(jobQueued{ID: 2}, 10*time.Millisecond))
+	assert.NoError(t, eventbus.PublishAsyncTTL(jobQueued{ID: 3}, time.Minute))
+	clock.Advance(time.Second)
+	close(release)
+
+	assert.Equal(t, 1, <-handled)
+	assert.Equal(t, 3, <-handled)
+	assert.Equal(t, expired+1, eventbus.ExpiredCount())
+	lock.Lock()
+	defer lock.Unlock()
+	if assert.Len(t, errs, 1) {
+		assert.True(t, errors.Is(errs[0], eventbus.ErrExpired))
+	}
+}
+
+func TestFakeClock_Timer(t *testing.T) {
+	start := time.Now()
+	clock := NewFakeClock(start)
+
+	timer := clock.NewTimer(time.Minute)
+	assert.Equal(t, 1, clock.Timers())
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), clock.Now())
+	assert.True(t, timer.Reset(time.Minute))
+	clock.Advance(30 * time.Second)
+	assert.Empty(t, timer.C())
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(90*time.Second), <-timer.C())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, 0, clock.Timers())
+
+	assert.Equal(t, start.Add(90*time.Second), <-clock.After(0))
+}
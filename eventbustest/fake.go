@@ -0,0 +1,127 @@
+// Package eventbustest provides a fake event bus for testing code that publishes
+// or subscribes to events through the eventbus.Publisher, eventbus.Subscriber and
+// eventbus.Topic interfaces, and a fake clock for testing the time based features
+// of the bus.
+package eventbustest
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/jkratz55/eventbus-go"
+)
+
+type fakeHandler struct {
+	id     uint64
+	handle func(event any)
+}
+
+// FakeBus is an in-memory event bus which records the events published to it. It
+// is isolated from the package level bus of eventbus and from other fakes, so
+// tests using it can run in parallel. Events are delivered to handlers
+// subscribed to the fake synchronously, including those published with
+// PublishAsync, to keep tests deterministic.
+type FakeBus struct {
+	mu        sync.Mutex
+	published []any
+	handlers  map[reflect.Type][]fakeHandler
+	nextID    uint64
+}
+
+// NewFakeBus returns an empty FakeBus.
+func NewFakeBus() *FakeBus {
+	return &FakeBus{handlers: make(map[reflect.Type][]fakeHandler)}
+}
+
+// Topic returns an eventbus.Topic for events of type T backed by the fake.
+func Topic[T any](bus *FakeBus) eventbus.Topic[T] {
+	return fakeTopic[T]{bus: bus}
+}
+
+// Published returns the events of type T published to the fake in the order they
+// were published.
+func Published[T any](bus *FakeBus) []T {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	var events []T
+	for _, event := range bus.published {
+		if e, ok := event.(T); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Events returns the events of every type published to the fake in the order
+// they were published.
+func (b *FakeBus) Events() []any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]any(nil), b.published...)
+}
+
+// Reset forgets the events published to the fake. Subscriptions are kept.
+func (b *FakeBus) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.published = nil
+}
+
+type fakeTopic[T any] struct {
+	bus *FakeBus
+}
+
+func (t fakeTopic[T]) Publish(event T) error {
+	eventType := eventbus.TypeOf[T]()
+
+	t.bus.mu.Lock()
+	t.bus.published = append(t.bus.published, event)
+	handlers := append([]fakeHandler(nil), t.bus.handlers[eventType]...)
+	t.bus.mu.Unlock()
+
+	// Handlers are invoked without holding the lock so they can publish events
+	// themselves.
+	for _, h := range handlers {
+		h.handle(event)
+	}
+	return nil
+}
+
+func (t fakeTopic[T]) PublishAsync(event T) error {
+	return t.Publish(event)
+}
+
+func (t fakeTopic[T]) Subscribe(handler eventbus.Handler[T]) uint64 {
+	eventType := eventbus.TypeOf[T]()
+
+	t.bus.mu.Lock()
+	defer t.bus.mu.Unlock()
+
+	t.bus.nextID++
+	t.bus.handlers[eventType] = append(t.bus.handlers[eventType], fakeHandler{
+		id: t.bus.nextID,
+		handle: func(event any) {
+			handler.OnEvent(event.(T))
+		},
+	})
+	return t.bus.nextID
+}
+
+func (t fakeTopic[T]) Unsubscribe(subscriptionID uint64) bool {
+	eventType := eventbus.TypeOf[T]()
+
+	t.bus.mu.Lock()
+	defer t.bus.mu.Unlock()
+
+	handlers := t.bus.handlers[eventType]
+	for i, h := range handlers {
+		if h.id == subscriptionID {
+			t.bus.handlers[eventType] = append(handlers[:i:i], handlers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
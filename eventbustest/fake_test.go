@@ -0,0 +1,57 @@
+package eventbustest
+
+import (
+	"testing"
+
+	"github.com/jkratz55/eventbus-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type userCreated struct {
+	Name string
+}
+
+type welcomeSent struct {
+	Name string
+}
+
+// signup is an example of a consumer of eventbus that has its publisher
+// injected.
+type signup struct {
+	events eventbus.Publisher[userCreated]
+}
+
+func (s signup) register(name string) error {
+	return s.events.Publish(userCreated{Name: name})
+}
+
+func TestFakeBus_RecordsPublishes(t *testing.T) {
+	bus := NewFakeBus()
+	s := signup{events: Topic[userCreated](bus)}
+
+	assert.NoError(t, s.register("John Doe"))
+	assert.NoError(t, s.register("Jane Doe"))
+
+	assert.Equal(t, []userCreated{{Name: "John Doe"}, {Name: "Jane Doe"}}, Published[userCreated](bus))
+	assert.Empty(t, Published[welcomeSent](bus))
+
+	bus.Reset()
+	assert.Empty(t, bus.Events())
+}
+
+func TestFakeBus_DeliversToSubscribers(t *testing.T) {
+	bus := NewFakeBus()
+	welcomes := Topic[welcomeSent](bus)
+	id := Topic[userCreated](bus).Subscribe(eventbus.HandlerFunc[userCreated](func(event userCreated) {
+		_ = welcomes.PublishAsync(welcomeSent{Name: event.Name})
+	}))
+
+	s := signup{events: Topic[userCreated](bus)}
+	assert.NoError(t, s.register("John Doe"))
+	assert.Equal(t, []any{userCreated{Name: "John Doe"}, welcomeSent{Name: "John Doe"}}, bus.Events())
+
+	assert.True(t, Topic[userCreated](bus).Unsubscribe(id))
+	assert.False(t, Topic[userCreated](bus).Unsubscribe(id))
+	assert.NoError(t, s.register("Jane Doe"))
+	assert.Equal(t, []welcomeSent{{Name: "John Doe"}}, Published[welcomeSent](bus))
+}
@@ -0,0 +1,125 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// filters holds the global filter chain of each event type, in registration
+// order.
+var filters = make(map[reflect.Type][]filterEntry)
+
+// transforms holds the transforms of each event type, in registration order.
+var transforms = make(map[reflect.Type][]transformEntry)
+
+// filterEntry holds a filter both as the func(T) (T, bool) it was added as, so
+// filtering events of a static type doesn't box them, and bound to events of any
+// type for events published with PublishDynamic.
+type filterEntry struct {
+	typed   any
+	dynamic func(event any) (any, bool)
+}
+
+// transformEntry holds a transform both as the func(T) T it was added as and
+// bound to events of any type, like filterEntry.
+type transformEntry struct {
+	typed   any
+	dynamic func(event any) any
+}
+
+// AddFilter appends a filter to the chain of filters for events of type T. Every
+// event published is passed through the chain in registration order before it is
+// delivered to any handler. A filter may transform the event by returning a new
+// value, which replaces the event for the filters and handlers that follow, or
+// drop the event entirely by returning false. Dropped events are not delivered
+// and Publish returns nil.
+//
+// Like handlers, filters are invoked without the bus locked, so they may publish
+// events and subscribe or unsubscribe handlers. Filters added while an event is
+// being filtered don't apply to it. Transforms added with AddTransform run after
+// the filters.
+func AddFilter[T any](fn func(T) (T, bool)) {
+	mustNotBeNil(fn)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+	filters[eventType] = append(filters[eventType], filterEntry{
+		typed: fn,
+		dynamic: func(event any) (any, bool) {
+			return fn(event.(T))
+		},
+	})
+}
+
+// AddTransform appends a transform to the transforms of events of type T, a stage
+// run once for every event published, after the event has passed the filter
+// chain and before it is recorded or delivered to any handler. The event returned
+// by the transform replaces the event for the transforms that follow and for the
+// whole chain of handlers, such as to redact a field before any handler sees it,
+// whereas decorators such as WithLogging only wrap a single handler. Unlike
+// filters transforms can't drop events, and if the event implements Cloneable it
+// is cloned before the first transform, so a transform may modify the slices and
+// maps of the event without the publisher observing the change.
+//
+// Like filters, transforms are invoked without the bus locked, so they may
+// publish events and subscribe or unsubscribe handlers.
+func AddTransform[T any](fn func(event T) T) {
+	mustNotBeNil(fn)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+	transforms[eventType] = append(transforms[eventType], transformEntry{
+		typed: fn,
+		dynamic: func(event any) any {
+			return fn(event.(T))
+		},
+	})
+}
+
+// applyFilters passes the event through the filter chain of its type and then
+// its transforms, returning the resulting event and whether it should be
+// delivered. The filters and transforms are invoked with the read lock released.
+// The caller must hold the read lock.
+func applyFilters[T any](eventType reflect.Type, event T) (T, bool) {
+	for _, f := range filters[eventType] {
+		var ok bool
+		unlocked(func() {
+			if fn, typed := f.typed.(func(T) (T, bool)); typed {
+				event, ok = fn(event)
+			} else {
+				var filtered any
+				filtered, ok = f.dynamic(event)
+				event = filtered.(T)
+			}
+		})
+		if !ok {
+			return event, false
+		}
+	}
+	return applyTransforms(eventType, event), true
+}
+
+// applyTransforms passes the event through the transforms of its type, cloning
+// it first if it implements Cloneable. The caller must hold the read lock.
+func applyTransforms[T any](eventType reflect.Type, event T) T {
+	chain := transforms[eventType]
+	if len(chain) == 0 {
+		return event
+	}
+	if c, ok := any(event).(Cloneable[T]); ok {
+		event = c.Clone()
+	}
+	for _, t := range chain {
+		unlocked(func() {
+			if fn, typed := t.typed.(func(T) T); typed {
+				event = fn(event)
+			} else {
+				event = t.dynamic(event).(T)
+			}
+		})
+	}
+	return event
+}
@@ -0,0 +1,151 @@
+package eventbus
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFilter(t *testing.T) {
+	reset()
+	AddFilter(func(event userCreatedEvent) (userCreatedEvent, bool) {
+		event.Email = strings.ToLower(event.Email)
+		return event, true
+	})
+	AddFilter(func(event userCreatedEvent) (userCreatedEvent, bool) {
+		return event, !strings.HasSuffix(event.Email, "@spam.com")
+	})
+
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}).Return()
+	Subscribe[userCreatedEvent](h)
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe", Email: "JDoe@Gmail.com"}))
+	assert.NoError(t, Publish(userCreatedEvent{Name: "Spammer", Email: "Bot@SPAM.com"}))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+	h.AssertExpectations(t)
+}
+
+func TestAddFilter_Order(t *testing.T) {
+	reset()
+	var order []int
+	for i := 1; i <= 3; i++ {
+		i := i
+		AddFilter(func(event progressEvent) (progressEvent, bool) {
+			order = append(order, i)
+			event.Count = event.Count*10 + i
+			return event, true
+		})
+	}
+
+	var received progressEvent
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = event
+	}))
+
+	assert.NoError(t, Publish(progressEvent{}))
+	assert.Equal(t, []int{1, 2, 3}, order)
+	assert.Equal(t, 123, received.Count)
+}
+
+func TestAddFilter_Async(t *testing.T) {
+	reset()
+	AddFilter(func(event progressEvent) (progressEvent, bool) {
+		return event, event.Count%2 == 0
+	})
+
+	received := make(chan progressEvent, 2)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received <- event
+	}))
+
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	assert.Equal(t, progressEvent{Count: 2}, <-received)
+	assert.Empty(t, received)
+}
+
+func TestAddFilter_Nil(t *testing.T) {
+	reset()
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		AddFilter[progressEvent](nil)
+	})
+}
+
+type signupEvent struct {
+	Email      string
+	Attributes map[string]string
+}
+
+func (e signupEvent) Clone() signupEvent {
+	attributes := make(map[string]string, len(e.Attributes))
+	for k, v := range e.Attributes {
+		attributes[k] = v
+	}
+	e.Attributes = attributes
+	return e
+}
+
+func TestAddTransform(t *testing.T) {
+	reset()
+	var filtered []string
+	AddFilter(func(event signupEvent) (signupEvent, bool) {
+		filtered = append(filtered, event.Email)
+		return event, true
+	})
+	AddTransform(func(event signupEvent) signupEvent {
+		event.Email = "redacted"
+		delete(event.Attributes, "ssn")
+		return event
+	})
+
+	var received []signupEvent
+	for i := 0; i < 2; i++ {
+		Subscribe[signupEvent](HandlerFunc[signupEvent](func(event signupEvent) {
+			received = append(received, event)
+		}))
+	}
+
+	event := signupEvent{Email: "jdoe@gmail.com", Attributes: map[string]string{"ssn": "123-45-6789", "plan": "pro"}}
+	assert.NoError(t, Publish(event))
+
+	// Filters run before transforms and see the event as published
+	assert.Equal(t, []string{"jdoe@gmail.com"}, filtered)
+	redacted := signupEvent{Email: "redacted", Attributes: map[string]string{"plan": "pro"}}
+	assert.Equal(t, []signupEvent{redacted, redacted}, received)
+	assert.Equal(t, map[string]string{"ssn": "123-45-6789", "plan": "pro"}, event.Attributes)
+}
+
+func TestAddTransform_Nil(t *testing.T) {
+	reset()
+	assert.PanicsWithValue(t, ErrNilHandler, func() {
+		AddTransform[progressEvent](nil)
+	})
+}
+
+func TestAddFilter_SubscribeFromFilter(t *testing.T) {
+	reset()
+	AddFilter(func(event userCreatedEvent) (userCreatedEvent, bool) {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+		return event, true
+	})
+	AddTransform(func(event userCreatedEvent) userCreatedEvent {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+		return event
+	})
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {}))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- Publish(userCreatedEvent{Name: "John Doe"})
+	}()
+	select {
+	case err := <-published:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked subscribing from a filter")
+	}
+	assert.Len(t, Subscriptions[orderEvent](), 2)
+}
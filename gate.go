@@ -0,0 +1,32 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// SubscribeGated registers a handler for a given type that is only invoked while
+// enabled returns true, such as a handler behind a feature flag. Enabled is
+// called each time an event is dispatched and the handler is skipped when it
+// returns false, without being unsubscribed, so it receives events again once
+// enabled returns true. For PublishAsync enabled is called when the event is
+// published rather than when the handler runs. Enabled is called while the bus
+// holds its read lock, so it must not subscribe or unsubscribe handlers. The
+// return value is a subscription ID that can be used to unsubscribe the handler.
+func SubscribeGated[T any](handler Handler[T], enabled func() bool) uint64 {
+	mustNotBeNil(handler)
+	mustNotBeNil(enabled)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+	entryByID(eventType, id).enabled = enabled
+	return id
+}
+
+// gated reports whether the handler of the entry is disabled by the gate it was
+// subscribed with using SubscribeGated.
+func (h handlerEntry) gated() bool {
+	return h.enabled != nil && !h.enabled()
+}
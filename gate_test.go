@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeGated(t *testing.T) {
+	reset()
+
+	var enabled atomic.Bool
+	var received []int
+	SubscribeGated[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event.Count)
+	}), enabled.Load)
+	var all []int
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		all = append(all, event.Count)
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	enabled.Store(true)
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.NoError(t, Publish(progressEvent{Count: 3}))
+	enabled.Store(false)
+	assert.NoError(t, Publish(progressEvent{Count: 4}))
+
+	assert.Equal(t, []int{2, 3}, received)
+	assert.Equal(t, []int{1, 2, 3, 4}, all)
+}
+
+func TestSubscribeGated_Balanced(t *testing.T) {
+	reset()
+
+	SubscribeGated[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		t.Error("disabled handler was invoked")
+	}), func() bool { return false })
+	assert.Error(t, PublishBalanced(progressEvent{Count: 1}))
+}
@@ -0,0 +1,113 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// piper is implemented by handlers that publish an event of another type for
+// each event they handle, such as the pipes of SubscribePipe.
+type piper interface {
+	pipesTo() reflect.Type
+}
+
+// Graph describes the subscriptions registered with eventbus as a graph of event
+// types, see ExportGraph.
+type Graph struct {
+	// Nodes contains the event types with handlers or piped to, sorted by name.
+	Nodes []GraphNode `json:"nodes"`
+	// Edges contains the pipes between event types, sorted by the names of the
+	// types they connect.
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is an event type in a Graph. Event types are named by the TypeNamer
+// configured with WithTypeNamer.
+type GraphNode struct {
+	Type     string `json:"type"`
+	Handlers int    `json:"handlers"`
+}
+
+// GraphEdge is a pipe, subscribed with SubscribePipe, publishing events of the
+// To type for the events of the From type it handles.
+type GraphEdge struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	SubscriptionID uint64 `json:"subscriptionId"`
+}
+
+// ExportGraph returns the current subscription topology as a graph, with a node
+// for each event type with the number of handlers registered for it, and an edge
+// for each pipe between types, which helps document and debug how events flow
+// through an application. The graph can be encoded to JSON, or rendered with
+// Graphviz using ExportDOT.
+func ExportGraph() Graph {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	counts := make(map[string]int, len(handlers))
+	edges := make([]GraphEdge, 0)
+	for eventType, entries := range handlers {
+		if len(entries) == 0 {
+			continue
+		}
+		from := typeName(eventType)
+		counts[from] += len(entries)
+		for _, h := range entries {
+			p, ok := h.handler.(piper)
+			if !ok {
+				continue
+			}
+			to := typeName(p.pipesTo())
+			if _, ok := counts[to]; !ok {
+				counts[to] = 0
+			}
+			edges = append(edges, GraphEdge{From: from, To: to, SubscriptionID: h.id})
+		}
+	}
+
+	nodes := make([]GraphNode, 0, len(counts))
+	for name, n := range counts {
+		nodes = append(nodes, GraphNode{Type: name, Handlers: n})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Type < nodes[j].Type
+	})
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].SubscriptionID < edges[j].SubscriptionID
+	})
+	return Graph{Nodes: nodes, Edges: edges}
+}
+
+// ExportDOT returns the graph returned by ExportGraph in the DOT language of
+// Graphviz, with each event type labeled with its number of handlers.
+//
+//	os.WriteFile("events.dot", []byte(eventbus.ExportDOT()), 0o644)
+//	// dot -Tsvg events.dot -o events.svg
+func ExportDOT() string {
+	graph := ExportGraph()
+
+	var b strings.Builder
+	b.WriteString("digraph eventbus {\n")
+	for _, node := range graph.Nodes {
+		label := fmt.Sprintf("%s\n%d handlers", node.Type, node.Handlers)
+		if node.Handlers == 1 {
+			label = fmt.Sprintf("%s\n1 handler", node.Type)
+		}
+		fmt.Fprintf(&b, "\t%s [label=%s];\n", strconv.Quote(node.Type), strconv.Quote(label))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
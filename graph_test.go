@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportGraph(t *testing.T) {
+	reset()
+	pipe := SubscribePipe(func(event orderEvent) (progressEvent, error) {
+		return progressEvent{Count: event.Seq}, nil
+	})
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {}))
+
+	assert.Equal(t, Graph{
+		Nodes: []GraphNode{
+			{Type: "orderEvent", Handlers: 2},
+			{Type: "progressEvent", Handlers: 0},
+			{Type: "userCreatedEvent", Handlers: 1},
+		},
+		Edges: []GraphEdge{
+			{From: "orderEvent", To: "progressEvent", SubscriptionID: pipe},
+		},
+	}, ExportGraph())
+
+	assert.Equal(t, `digraph eventbus {
+	"orderEvent" [label="orderEvent\n2 handlers"];
+	"progressEvent" [label="progressEvent\n0 handlers"];
+	"userCreatedEvent" [label="userCreatedEvent\n1 handler"];
+	"orderEvent" -> "progressEvent";
+}
+`, ExportDOT())
+}
+
+func TestExportGraph_Empty(t *testing.T) {
+	reset()
+	assert.Equal(t, Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}, ExportGraph())
+	assert.Equal(t, "digraph eventbus {\n}\n", ExportDOT())
+}
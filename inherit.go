@@ -0,0 +1,101 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+type priorityKey struct{}
+
+var (
+	prioritiesMu = sync.Mutex{}
+	// priorities holds, for each goroutine invoking handlers while priority
+	// inheritance is enabled with WithPriorityInheritance, the priorities of the
+	// handlers being invoked with the innermost last.
+	priorities = make(map[uint64][]int)
+)
+
+// InheritedPriority returns the priority inherited by the event being handled by
+// a ContextHandler, which is passed the context, and whether there is one. Events
+// published by a handler inherit its priority when priority inheritance is
+// enabled with WithPriorityInheritance, as do events published with a context
+// carrying the priority, such as with PublishAsyncCtx.
+func InheritedPriority(ctx context.Context) (int, bool) {
+	priority, ok := ctx.Value(priorityKey{}).(int)
+	return priority, ok
+}
+
+// inheriting gives the delivery the priority of the handler invoked on the
+// publishing goroutine, or carried by the context of the delivery, if priority
+// inheritance is enabled and the delivery doesn't have a priority of its own. The
+// priority is also carried by the context of the delivery so ContextHandlers can
+// observe it. The caller must hold the read lock.
+func (d delivery) inheriting() delivery {
+	if !cfg.priorityInheritance || d.priority != nil {
+		return d
+	}
+	var priority int
+	var ok bool
+	if d.ctx != nil {
+		priority, ok = InheritedPriority(d.ctx)
+	}
+	if !ok {
+		if priority, ok = currentPriority(); !ok {
+			return d
+		}
+	}
+	d.priority = &priority
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d.ctx = context.WithValue(ctx, priorityKey{}, priority)
+	return d
+}
+
+// currentPriority returns the priority of the innermost handler being invoked on
+// the current goroutine, and whether there is one.
+func currentPriority() (int, bool) {
+	id := goroutineID()
+	prioritiesMu.Lock()
+	defer prioritiesMu.Unlock()
+
+	if stack := priorities[id]; len(stack) > 0 {
+		return stack[len(stack)-1], true
+	}
+	return 0, false
+}
+
+// enterPriority records that a handler with the given priority is being invoked
+// on the current goroutine, so events published by it inherit the priority,
+// returning a function that removes the record again.
+func enterPriority(priority int) func() {
+	id := goroutineID()
+	prioritiesMu.Lock()
+	priorities[id] = append(priorities[id], priority)
+	prioritiesMu.Unlock()
+
+	return func() {
+		prioritiesMu.Lock()
+		defer prioritiesMu.Unlock()
+
+		stack := priorities[id]
+		if len(stack) <= 1 {
+			delete(priorities, id)
+			return
+		}
+		priorities[id] = stack[:len(stack)-1]
+	}
+}
+
+// prioritizing wraps invoke so events published by the handler with the given
+// priority on its asynchronous task inherit the priority of the delivery, or
+// else of the handler.
+func (d delivery) prioritizing(handlerPriority int, invoke func(event any) error) func(event any) error {
+	priority := d.queuePriority(handlerPriority)
+	return func(event any) error {
+		defer enterPriority(priority)()
+		return invoke(event)
+	}
+}
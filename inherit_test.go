@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type inheritance struct {
+	priority int
+	ok       bool
+}
+
+func TestWithPriorityInheritance(t *testing.T) {
+	reset()
+	Configure(WithPriorityInheritance())
+
+	observed := make(chan inheritance, 2)
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		priority, ok := InheritedPriority(ctx)
+		observed <- inheritance{priority: priority, ok: ok}
+		return nil
+	}))
+	SubscribePriority[orderEvent](5, HandlerFunc[orderEvent](func(event orderEvent) {
+		assert.NoError(t, Publish(progressEvent{Count: event.Seq}))
+		assert.NoError(t, PublishAsync(progressEvent{Count: event.Seq}))
+	}))
+
+	// Both the synchronous and the asynchronous nested publish inherit the priority
+	// of the handler publishing them
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-observed:
+			assert.Equal(t, inheritance{priority: 5, ok: true}, got)
+		case <-time.After(time.Second):
+			t.Fatal("nested event was not handled")
+		}
+	}
+	assert.Eventually(t, func() bool {
+		prioritiesMu.Lock()
+		defer prioritiesMu.Unlock()
+		return len(priorities) == 0
+	}, time.Second, time.Millisecond)
+
+	// Events published outside of a handler don't inherit a priority
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.Equal(t, inheritance{}, <-observed)
+}
+
+func TestWithPriorityInheritance_Disabled(t *testing.T) {
+	reset()
+	observed := make(chan inheritance, 1)
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		priority, ok := InheritedPriority(ctx)
+		observed <- inheritance{priority: priority, ok: ok}
+		return nil
+	}))
+	SubscribePriority[orderEvent](5, HandlerFunc[orderEvent](func(event orderEvent) {
+		assert.NoError(t, Publish(progressEvent{Count: event.Seq}))
+	}))
+
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+	assert.Equal(t, inheritance{}, <-observed)
+}
@@ -0,0 +1,115 @@
+package eventbus
+
+import (
+	"reflect"
+	"time"
+)
+
+// SubscriptionInfo describes a handler registered with eventbus.
+type SubscriptionInfo struct {
+	// ID is the subscription ID returned when the handler was subscribed.
+	ID uint64
+	// Name is the name the handler was subscribed with using SubscribeNamed.
+	Name string
+	// Source is the file and line number the handler was subscribed from in the
+	// form "file.go:42". Source is only populated when caller info capture is
+	// enabled with WithCaptureCallerInfo.
+	Source string
+	// Quarantined reports whether the handler has been quarantined onto its own
+	// goroutine because it was too slow. See WithQuarantine.
+	Quarantined bool
+	// Type is the event type the handler was subscribed to. It is only set by
+	// StaleSubscriptions.
+	Type reflect.Type
+}
+
+// Subscriptions returns information about all the handlers currently registered
+// for the given type in the order they were registered.
+func Subscriptions[T any]() []SubscriptionInfo {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	eventType := reflect.TypeOf(*new(T))
+	entries := handlers[eventType]
+	infos := make([]SubscriptionInfo, 0, len(entries))
+	for _, h := range entries {
+		infos = append(infos, subscriptionInfo(h))
+	}
+	return infos
+}
+
+// StaleSubscriptions returns information about the handlers that haven't been
+// invoked within olderThan, nor subscribed within it, such as handlers
+// subscribed to a type that is never published because of dead code or a typo.
+// Combined with WithCaptureCallerInfo this helps find where dead subscriptions
+// are made. Unlike Subscriptions, handlers of every type are listed, so the Type
+// of each SubscriptionInfo is set. The order of the handlers is unspecified,
+// although handlers for the same type are listed in registration order.
+func StaleSubscriptions(olderThan time.Duration) []SubscriptionInfo {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	cutoff := now().Add(-olderThan).UnixNano()
+	infos := make([]SubscriptionInfo, 0)
+	for eventType, entries := range handlers {
+		for _, h := range entries {
+			if h.lastInvoked.Load() > cutoff {
+				continue
+			}
+			info := subscriptionInfo(h)
+			info.Type = eventType
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+func subscriptionInfo(h handlerEntry) SubscriptionInfo {
+	return SubscriptionInfo{
+		ID:          h.id,
+		Name:        h.name,
+		Source:      h.source,
+		Quarantined: h.health != nil && h.health.quarantined.Load(),
+	}
+}
+
+// touch records that the handler of the entry is being invoked.
+func (e handlerEntry) touch() {
+	e.lastInvoked.Store(now().UnixNano())
+}
+
+// touching wraps invoke so each invocation of the handler of the entry is
+// recorded.
+func (e handlerEntry) touching(invoke func(event any) error) func(event any) error {
+	return func(event any) error {
+		e.touch()
+		return invoke(event)
+	}
+}
+
+// ForEachSubscription invokes fn for every handler registered with eventbus,
+// providing the event type and subscription ID of each. The subscriptions are
+// snapshotted under the read lock before fn is invoked, so fn may safely
+// subscribe, unsubscribe or publish, but changes made while iterating are not
+// reflected in the iteration. Fallback handlers are not bound to an event type
+// and are not included. The order event types are visited in is unspecified,
+// although handlers for the same type are visited in registration order.
+func ForEachSubscription(fn func(eventType reflect.Type, id uint64)) {
+	type subscription struct {
+		eventType reflect.Type
+		id        uint64
+	}
+
+	mu.RLock()
+	snapshot := make([]subscription, 0)
+	for eventType, entries := range handlers {
+		for _, h := range entries {
+			snapshot = append(snapshot, subscription{eventType: eventType, id: h.id})
+		}
+	}
+	mu.RUnlock()
+
+	for _, s := range snapshot {
+		fn(s.eventType, s.id)
+	}
+}
@@ -0,0 +1,69 @@
+package eventbus
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptions(t *testing.T) {
+	reset()
+	id1 := Subscribe[userCreatedEvent](new(userCreatedHandler))
+	id2 := Subscribe[userCreatedEvent](new(userCreatedHandler))
+
+	assert.Equal(t, []SubscriptionInfo{{ID: id1}, {ID: id2}}, Subscriptions[userCreatedEvent]())
+	assert.Empty(t, Subscriptions[string]())
+}
+
+func TestSubscriptions_CaptureCallerInfo(t *testing.T) {
+	reset()
+	Configure(WithCaptureCallerInfo())
+
+	_, file, line, _ := runtime.Caller(0)
+	id := Subscribe[userCreatedEvent](new(userCreatedHandler))
+
+	expected := fmt.Sprintf("%s:%d", filepath.Base(file), line+1)
+	assert.Equal(t, []SubscriptionInfo{{ID: id, Source: expected}}, Subscriptions[userCreatedEvent]())
+}
+
+func TestForEachSubscription(t *testing.T) {
+	reset()
+	id1 := Subscribe[userCreatedEvent](new(userCreatedHandler))
+	id2 := Subscribe[userCreatedEvent](new(userCreatedHandler))
+	id3 := Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {}))
+	SubscribeFallback(func(event any) {})
+
+	visited := make(map[uint64]reflect.Type)
+	ForEachSubscription(func(eventType reflect.Type, id uint64) {
+		_, seen := visited[id]
+		assert.False(t, seen)
+		visited[id] = eventType
+
+		// Callback can safely call back into eventbus
+		Subscribe[string](HandlerFunc[string](func(event string) {}))
+	})
+
+	assert.Equal(t, map[uint64]reflect.Type{
+		id1: reflect.TypeOf(userCreatedEvent{}),
+		id2: reflect.TypeOf(userCreatedEvent{}),
+		id3: reflect.TypeOf(progressEvent{}),
+	}, visited)
+}
+
+func TestStaleSubscriptions(t *testing.T) {
+	reset()
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {}))
+	stale := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	// Recently subscribed handlers aren't stale yet
+	assert.Empty(t, StaleSubscriptions(time.Hour))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.Equal(t, []SubscriptionInfo{{ID: stale, Type: reflect.TypeOf(progressEvent{})}}, StaleSubscriptions(10*time.Millisecond))
+}
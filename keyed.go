@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SubscribeKeyed registers a handler for a given type under a user-defined key.
+// Multiple handlers may be registered under the same key, and all of them can be
+// removed at once with UnsubscribeKey. This is useful for modeling named topics
+// within an event type. The key must be comparable, SubscribeKeyed panics if the
+// key is nil or not comparable. The return value is a subscription ID that can
+// also be used to unsubscribe the handler with Unsubscribe.
+func SubscribeKeyed[T any](key any, handler Handler[T]) uint64 {
+	if key == nil || !reflect.ValueOf(key).Comparable() {
+		panic(fmt.Sprintf("eventbus: subscription key of type %T is not comparable", key))
+	}
+
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+	entryByID(eventType, id).key = key
+	return id
+}
+
+// UnsubscribeKey removes all handlers for the specified type that were registered
+// with SubscribeKeyed using the given key. The return value is the number of
+// handlers removed.
+func UnsubscribeKey[T any](key any) int {
+	if key == nil || !reflect.ValueOf(key).Comparable() {
+		return 0
+	}
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	return removeWhere(reflect.TypeOf(*new(T)), func(entry handlerEntry) bool {
+		return entry.key != nil && entry.key == key
+	})
+}
+
+// removeWhere removes all handler entries for the event type matching pred and
+// returns the number of entries removed. The caller must hold the write lock.
+func removeWhere(eventType reflect.Type, pred func(entry handlerEntry) bool) int {
+	entries := handlers[eventType]
+	remaining := make([]handlerEntry, 0, len(entries))
+	for _, h := range entries {
+		if pred(h) {
+			release(eventType, h)
+			continue
+		}
+		remaining = append(remaining, h)
+	}
+
+	removed := len(entries) - len(remaining)
+	if removed > 0 {
+		handlers[eventType] = remaining
+	}
+	return removed
+}
@@ -0,0 +1,37 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeKeyed(t *testing.T) {
+	reset()
+	invoked := 0
+	h := HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		invoked++
+	})
+	SubscribeKeyed[userCreatedEvent]("audit", h)
+	SubscribeKeyed[userCreatedEvent]("audit", h)
+	id := SubscribeKeyed[userCreatedEvent]("email", h)
+
+	assert.Equal(t, 2, UnsubscribeKey[userCreatedEvent]("audit"))
+	assert.Equal(t, 0, UnsubscribeKey[userCreatedEvent]("audit"))
+	assert.Equal(t, []SubscriptionInfo{{ID: id}}, Subscriptions[userCreatedEvent]())
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	assert.Equal(t, 1, invoked)
+}
+
+func TestSubscribeKeyed_NonComparableKey(t *testing.T) {
+	reset()
+	h := HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {})
+	assert.Panics(t, func() {
+		SubscribeKeyed[userCreatedEvent]([]string{"audit"}, h)
+	})
+	assert.Panics(t, func() {
+		SubscribeKeyed[userCreatedEvent](nil, h)
+	})
+	assert.Empty(t, Subscriptions[userCreatedEvent]())
+}
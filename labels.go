@@ -0,0 +1,46 @@
+package eventbus
+
+import (
+	"maps"
+	"reflect"
+)
+
+// SubscribeLabeled registers a handler for a given type with a set of labels,
+// such as region=us, which events published with PublishSelected are routed by.
+// The handler also receives every event published with Publish or
+// PublishAsync. The labels are copied, so changing the map afterwards has no
+// effect. The return value is a subscription ID that can be used to unsubscribe
+// the handler with Unsubscribe.
+func SubscribeLabeled[T any](labels map[string]string, handler Handler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+	entryByID(eventType, id).labels = maps.Clone(labels)
+	return id
+}
+
+// PublishSelected behaves like Publish but only invokes the handlers whose labels,
+// given to SubscribeLabeled, include every label of the selector with the same
+// value, like a Kubernetes label selector. This routes events without a separate
+// event type for each destination. An empty selector selects every handler. If
+// there are handlers for the event type but none are selected no handler is
+// invoked and nil is returned.
+//
+//	err := eventbus.PublishSelected(order, map[string]string{"region": "eu"})
+func PublishSelected[T any](event T, selector map[string]string) error {
+	return publish(event, delivery{selector: selector})
+}
+
+// selects reports whether the labels include every label of the selector.
+func selects(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
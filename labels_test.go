@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishSelected(t *testing.T) {
+	reset()
+	var received []string
+	labels := map[string]string{"region": "us", "tier": "gold"}
+	SubscribeLabeled[orderEvent](labels, HandlerFunc[orderEvent](func(orderEvent) {
+		received = append(received, "us")
+	}))
+	SubscribeLabeled[orderEvent](map[string]string{"region": "eu"}, HandlerFunc[orderEvent](func(orderEvent) {
+		received = append(received, "eu")
+	}))
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {
+		received = append(received, "unlabeled")
+	}))
+	// The labels are copied when subscribing
+	labels["region"] = "eu"
+
+	assert.NoError(t, PublishSelected(orderEvent{Seq: 1}, map[string]string{"region": "eu"}))
+	assert.Equal(t, []string{"eu"}, received)
+
+	received = nil
+	assert.NoError(t, PublishSelected(orderEvent{Seq: 2}, map[string]string{"region": "us", "tier": "gold"}))
+	assert.Equal(t, []string{"us"}, received)
+
+	received = nil
+	assert.NoError(t, PublishSelected(orderEvent{Seq: 3}, map[string]string{"region": "ap"}))
+	assert.Empty(t, received)
+
+	received = nil
+	assert.NoError(t, Publish(orderEvent{Seq: 4}))
+	assert.Equal(t, []string{"us", "eu", "unlabeled"}, received)
+}
@@ -0,0 +1,100 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// latest holds the most recently published event for each type when latest
+// retention is enabled. It is a sync.Map since events are retained while
+// publishing, which only holds the read lock.
+var latest = sync.Map{}
+
+// retainLatest stores the event as the most recently published event of its type
+// if latest retention is enabled. The caller must hold the read lock.
+func retainLatest[T any](eventType reflect.Type, event T) {
+	if cfg.retainLatest {
+		latest.Store(eventType, event)
+	}
+}
+
+// catchUp buffers the events published to a handler while it is being caught up
+// with the retained event of its type by SubscribeLatest, so the handler receives
+// them after the retained event rather than concurrently with it.
+type catchUp[T any] struct {
+	handler Handler[T]
+	live    atomic.Bool
+
+	mu     sync.Mutex
+	buffer []T
+}
+
+func (c *catchUp[T]) invoke(event any) error {
+	if !c.live.Load() {
+		c.mu.Lock()
+		if !c.live.Load() {
+			c.buffer = append(c.buffer, event.(T))
+			c.mu.Unlock()
+			return nil
+		}
+		c.mu.Unlock()
+	}
+	c.handler.OnEvent(event.(T))
+	return nil
+}
+
+// goLive delivers the buffered events to the handler in the order they were
+// published, including events buffered while delivering, and then switches the
+// handler to receiving events as they are published.
+func (c *catchUp[T]) goLive() {
+	for {
+		c.mu.Lock()
+		buffered := c.buffer
+		c.buffer = nil
+		if len(buffered) == 0 {
+			c.live.Store(true)
+			c.mu.Unlock()
+			return
+		}
+		c.mu.Unlock()
+
+		for _, event := range buffered {
+			c.handler.OnEvent(event)
+		}
+	}
+}
+
+// SubscribeLatest registers a handler for a given type and, if latest retention
+// is enabled with WithLatestRetention and an event of the type has been
+// published, immediately invokes the handler with the most recently published
+// event before returning. This allows handlers that observe state to receive the
+// current state without waiting for the next event. After that the handler
+// receives events as they are published, the same as handlers registered with
+// Subscribe.
+//
+// The handoff from the retained event to live events is atomic: the handler
+// receives the retained event first, then the events published while it was
+// handling the retained event, which are buffered in the order they were
+// published, and then live events, so no event published after the retained
+// event is missed or delivered twice. The return value is a subscription ID that
+// can be used to unsubscribe the handler.
+func SubscribeLatest[T any](handler Handler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	eventType := reflect.TypeOf(*new(T))
+	event, ok := latest.Load(eventType)
+	if !ok {
+		id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+		unlockAndNotify()
+		return id
+	}
+	c := &catchUp[T]{handler: handler}
+	id := subscribe(eventType, handler, c.invoke, 2)
+	unlockAndNotify()
+
+	handler.OnEvent(event.(T))
+	c.goLive()
+	return id
+}
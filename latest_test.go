@@ -0,0 +1,103 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeLatest(t *testing.T) {
+	reset()
+	Configure(WithLatestRetention())
+
+	assert.Error(t, Publish(progressEvent{Count: 1}))
+	assert.Error(t, Publish(progressEvent{Count: 2}))
+
+	var received []progressEvent
+	SubscribeLatest[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event)
+	}))
+	assert.Equal(t, []progressEvent{{Count: 2}}, received)
+
+	assert.NoError(t, Publish(progressEvent{Count: 3}))
+	assert.Equal(t, []progressEvent{{Count: 2}, {Count: 3}}, received)
+}
+
+func TestSubscribeLatest_Handoff(t *testing.T) {
+	reset()
+	Configure(WithLatestRetention())
+	// A handler other than the one subscribed is needed for the events published
+	// before it to succeed
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	stop := make(chan struct{})
+	published := make(chan int)
+	go func() {
+		count := 0
+		for {
+			select {
+			case <-stop:
+				published <- count
+				return
+			default:
+			}
+			count++
+			assert.NoError(t, Publish(progressEvent{Count: count}))
+		}
+	}()
+	for {
+		if _, ok := latest.Load(reflect.TypeOf(progressEvent{})); ok {
+			break
+		}
+	}
+
+	// Handling the retained event slowly makes events published in the meantime
+	// be buffered
+	var received []int
+	first := true
+	SubscribeLatest[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		if first {
+			first = false
+			time.Sleep(5 * time.Millisecond)
+		}
+		received = append(received, event.Count)
+	}))
+	time.Sleep(time.Millisecond)
+	close(stop)
+	last := <-published
+
+	// The handler receives the retained event and every later event exactly once
+	// in order
+	assert.NotEmpty(t, received)
+	for i, count := range received {
+		if !assert.Equal(t, received[0]+i, count) {
+			break
+		}
+	}
+	assert.Greater(t, len(received), 1)
+	assert.Equal(t, last, received[len(received)-1])
+}
+
+func TestSubscribeLatest_NothingRetained(t *testing.T) {
+	reset()
+	Configure(WithLatestRetention())
+
+	invoked := false
+	SubscribeLatest[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		invoked = true
+	}))
+	assert.False(t, invoked)
+}
+
+func TestSubscribeLatest_RetentionDisabled(t *testing.T) {
+	reset()
+	assert.Error(t, Publish(progressEvent{Count: 1}))
+
+	invoked := false
+	SubscribeLatest[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		invoked = true
+	}))
+	assert.False(t, invoked)
+}
@@ -0,0 +1,79 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// ErrSubscriptionLeaked is reported to the error callback when leak detection is
+// enabled with WithLeakDetection and a Subscription is garbage collected while
+// its handler is still subscribed.
+var ErrSubscriptionLeaked = errors.New("eventbus: subscription leaked")
+
+// Subscription is a handle to a handler subscribed with SubscribeTracked. Unlike
+// the subscription IDs returned by the other Subscribe functions it can be
+// tracked by the garbage collector, allowing leaked subscriptions to be detected
+// with WithLeakDetection.
+type Subscription struct {
+	id          uint64
+	unsubscribe func() bool
+}
+
+// ID returns the subscription ID of the handler, which can also be used with
+// Unsubscribe.
+func (s *Subscription) ID() uint64 {
+	return s.id
+}
+
+// Unsubscribe removes the handler, returning false if it was already removed.
+func (s *Subscription) Unsubscribe() bool {
+	return s.unsubscribe()
+}
+
+// SubscribeTracked behaves like Subscribe but returns a Subscription handle rather
+// than a subscription ID. When leak detection is enabled with WithLeakDetection,
+// losing every reference to the handle without unsubscribing the handler is
+// reported as a leak once the handle is garbage collected.
+func SubscribeTracked[T any](handler Handler[T]) *Subscription {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+	s := &Subscription{
+		id: id,
+		unsubscribe: func() bool {
+			return Unsubscribe[T](id)
+		},
+	}
+	if cfg.leakDetection {
+		source := entryByID(eventType, id).source
+		runtime.SetFinalizer(s, func(s *Subscription) {
+			reportLeak(eventType, s.id, source)
+		})
+	}
+	return s
+}
+
+// reportLeak reports the subscription to the error callback if its handler is
+// still subscribed.
+func reportLeak(eventType reflect.Type, id uint64, source string) {
+	mu.RLock()
+	callback := cfg.errorCallback
+	subscribed := entryByID(eventType, id) != nil
+	name := typeName(eventType)
+	mu.RUnlock()
+
+	if !subscribed || callback == nil {
+		return
+	}
+	if source != "" {
+		callback(fmt.Errorf("%w: subscription %d for event %s subscribed at %s", ErrSubscriptionLeaked, id, name, source))
+		return
+	}
+	callback(fmt.Errorf("%w: subscription %d for event %s", ErrSubscriptionLeaked, id, name))
+}
@@ -0,0 +1,46 @@
+package eventbus
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLeakDetection(t *testing.T) {
+	reset()
+	var mu sync.Mutex
+	var errs []error
+	Configure(WithLeakDetection(), WithErrorCallback(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+
+	var leaked uint64
+	func() {
+		leaked = SubscribeTracked[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {})).ID()
+		s := SubscribeTracked[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+		assert.True(t, s.Unsubscribe())
+	}()
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, time.Second, 10*time.Millisecond)
+	// Give the finalizer of the unsubscribed handle the chance to run too
+	runtime.GC()
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrSubscriptionLeaked)
+		assert.EqualError(t, errs[0], "eventbus: subscription leaked: subscription "+strconv.FormatUint(leaked, 10)+" for event userCreatedEvent")
+	}
+}
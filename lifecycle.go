@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// SubscriptionAdded is published when WithLifecycleEvents is enabled and a
+// handler is subscribed.
+type SubscriptionAdded struct {
+	// Type is the event type the handler was subscribed to.
+	Type reflect.Type
+	// ID is the subscription ID of the handler.
+	ID uint64
+}
+
+// SubscriptionRemoved is published when WithLifecycleEvents is enabled and a
+// handler is unsubscribed.
+type SubscriptionRemoved struct {
+	// Type is the event type the handler was subscribed to.
+	Type reflect.Type
+	// ID is the subscription ID of the handler.
+	ID uint64
+}
+
+var (
+	subscriptionAddedType   = reflect.TypeOf(SubscriptionAdded{})
+	subscriptionRemovedType = reflect.TypeOf(SubscriptionRemoved{})
+)
+
+// pendingLifecycle holds the lifecycle events queued while the write lock is
+// held. They can't be published until the lock is released.
+var pendingLifecycle []func()
+
+// queueLifecycle queues a lifecycle event to be published once the write lock is
+// released by unlockAndNotify. Changes to subscriptions of the lifecycle events
+// themselves are not reported, so that monitoring subscriptions don't observe
+// themselves. The caller must hold the write lock.
+func queueLifecycle[T SubscriptionAdded | SubscriptionRemoved](eventType reflect.Type, event T) {
+	if !cfg.lifecycleEvents || eventType == subscriptionAddedType || eventType == subscriptionRemovedType {
+		return
+	}
+	pendingLifecycle = append(pendingLifecycle, func() {
+		// Lifecycle events are informational, nobody listening isn't an error.
+		_ = Publish(event)
+	})
+}
+
+// unlockAndNotify releases the write lock and then publishes any lifecycle events
+// queued while it was held.
+func unlockAndNotify() {
+	pending := pendingLifecycle
+	pendingLifecycle = nil
+	mu.Unlock()
+
+	for _, notify := range pending {
+		notify()
+	}
+}
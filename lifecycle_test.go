@@ -0,0 +1,50 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLifecycleEvents(t *testing.T) {
+	reset()
+	Configure(WithLifecycleEvents())
+
+	var added []SubscriptionAdded
+	var removed []SubscriptionRemoved
+	addedID := Subscribe[SubscriptionAdded](HandlerFunc[SubscriptionAdded](func(event SubscriptionAdded) {
+		added = append(added, event)
+	}))
+	removedID := Subscribe[SubscriptionRemoved](HandlerFunc[SubscriptionRemoved](func(event SubscriptionRemoved) {
+		removed = append(removed, event)
+	}))
+
+	// Subscribing to the lifecycle events doesn't publish lifecycle events
+	assert.Empty(t, added)
+
+	eventType := reflect.TypeOf(progressEvent{})
+	id := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	keyedID := SubscribeKeyed[progressEvent]("key", HandlerFunc[progressEvent](func(progressEvent) {}))
+	assert.Equal(t, []SubscriptionAdded{{Type: eventType, ID: id}, {Type: eventType, ID: keyedID}}, added)
+
+	assert.True(t, Unsubscribe[progressEvent](id))
+	assert.False(t, Unsubscribe[progressEvent](id))
+	assert.Equal(t, 1, UnsubscribeKey[progressEvent]("key"))
+	assert.Equal(t, []SubscriptionRemoved{{Type: eventType, ID: id}, {Type: eventType, ID: keyedID}}, removed)
+
+	assert.True(t, Unsubscribe[SubscriptionAdded](addedID))
+	assert.True(t, Unsubscribe[SubscriptionRemoved](removedID))
+	assert.Len(t, removed, 2)
+}
+
+func TestWithLifecycleEvents_Disabled(t *testing.T) {
+	reset()
+	var added []SubscriptionAdded
+	Subscribe[SubscriptionAdded](HandlerFunc[SubscriptionAdded](func(event SubscriptionAdded) {
+		added = append(added, event)
+	}))
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	assert.Empty(t, added)
+}
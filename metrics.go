@@ -0,0 +1,60 @@
+package eventbus
+
+import (
+	"reflect"
+	"time"
+)
+
+// MetricsCollector is notified of activity on the bus so it can be recorded as
+// metrics, such as with Prometheus using the eventbusprom package. Event types
+// are identified by the name given by the configured TypeNamer, which makes for
+// clean and stable label values. Implementations are invoked while publishing
+// and must be safe for concurrent use.
+type MetricsCollector interface {
+	// EventPublished is invoked each time an event is published, after it has
+	// passed any filters and before it is delivered to its handlers.
+	EventPublished(eventType string)
+	// HandlerCompleted is invoked each time a handler returns with how long it
+	// took and the error it returned, if any.
+	HandlerCompleted(eventType string, elapsed time.Duration, err error)
+}
+
+// PublishBlockObserver may be implemented by a MetricsCollector to observe how
+// long publishers are blocked waiting for room in the queue of the worker pool
+// under WithBlockOnQueueFull, a signal the bus is saturated. PublishBlocked is
+// invoked each time a handler invocation is queued, with a duration of zero if
+// the queue had room.
+type PublishBlockObserver interface {
+	PublishBlocked(eventType string, blocked time.Duration)
+}
+
+// recordPublished notifies the metrics collector, if there is one, that an event
+// of the type was published. The caller must hold the read lock.
+func recordPublished(eventType reflect.Type) {
+	if cfg.metrics != nil {
+		cfg.metrics.EventPublished(typeName(eventType))
+	}
+}
+
+// observeHandler must be deferred by the code invoking a handler. It notifies
+// the collector how long the handler took since start and the error it returned.
+func observeHandler(collector MetricsCollector, name string, start time.Time, err *error) {
+	collector.HandlerCompleted(name, time.Since(start), *err)
+}
+
+// observed wraps invoke so the collector is notified each time it returns.
+func observed(collector MetricsCollector, name string, invoke func(event any) error) func(event any) error {
+	return func(event any) (err error) {
+		defer observeHandler(collector, name, time.Now(), &err)
+		return invoke(event)
+	}
+}
+
+// recordBlocked notifies the metrics collector, if it observes blocked
+// publishers, how long publishing an event of the type was blocked. The caller
+// must hold the read lock.
+func recordBlocked(eventType reflect.Type, blocked time.Duration) {
+	if observer, ok := cfg.metrics.(PublishBlockObserver); ok {
+		observer.PublishBlocked(typeName(eventType), blocked)
+	}
+}
@@ -0,0 +1,77 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type handlerObservation struct {
+	eventType string
+	elapsed   time.Duration
+	err       error
+}
+
+type recordingCollector struct {
+	mu        sync.Mutex
+	published []string
+	completed []handlerObservation
+}
+
+func (c *recordingCollector) EventPublished(eventType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.published = append(c.published, eventType)
+}
+
+func (c *recordingCollector) HandlerCompleted(eventType string, elapsed time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.completed = append(c.completed, handlerObservation{eventType: eventType, elapsed: elapsed, err: err})
+}
+
+func TestWithMetrics(t *testing.T) {
+	reset()
+	collector := &recordingCollector{}
+	Configure(WithMetrics(collector), WithTypeNamer(func(eventType reflect.Type) string {
+		return "custom." + eventType.Name()
+	}))
+
+	handlerErr := errors.New("failed")
+	SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(progressEvent) error {
+		time.Sleep(5 * time.Millisecond)
+		return handlerErr
+	}))
+
+	assert.ErrorIs(t, Publish(progressEvent{Count: 1}), handlerErr)
+	assert.Equal(t, []string{"custom.progressEvent"}, collector.published)
+	assert.Len(t, collector.completed, 1)
+	assert.Equal(t, "custom.progressEvent", collector.completed[0].eventType)
+	assert.GreaterOrEqual(t, collector.completed[0].elapsed, 5*time.Millisecond)
+	assert.ErrorIs(t, collector.completed[0].err, handlerErr)
+
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	assert.Eventually(t, func() bool {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		return len(collector.completed) == 2
+	}, time.Second, time.Millisecond)
+	assert.Len(t, collector.published, 2)
+}
+
+func TestWithMetrics_Panic(t *testing.T) {
+	reset()
+	collector := &recordingCollector{}
+	Configure(WithMetrics(collector), WithPanicEvents())
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		panic("boom")
+	}))
+
+	assert.ErrorIs(t, Publish(progressEvent{Count: 1}), ErrHandlerPanic)
+	assert.Len(t, collector.completed, 1)
+	assert.ErrorIs(t, collector.completed[0].err, ErrHandlerPanic)
+}
@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// TypeOf returns the reflect.Type of events of type T, for use with
+// SubscribeMany.
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// SubscribeMany registers the same handler for each of the given event types,
+// which reduces boilerplate for handlers such as monitors that handle several
+// types of events. The handler receives events of every type as any, similar to
+// a fallback handler, and is expected to type switch as needed. The handlers are
+// subscribed under a single acquisition of the lock and their subscription IDs
+// are returned in the same order as the types. Each can be unsubscribed with
+// Unsubscribe for its type. SubscribeMany panics with ErrNilHandler if the
+// handler is nil.
+//
+//	ids := eventbus.SubscribeMany(monitor,
+//		eventbus.TypeOf[UserCreated](),
+//		eventbus.TypeOf[UserDeleted](),
+//	)
+func SubscribeMany(handler func(event any), types ...reflect.Type) []uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	invoke := func(event any) error {
+		handler(event)
+		return nil
+	}
+	ids := make([]uint64, 0, len(types))
+	for _, eventType := range types {
+		ids = append(ids, subscribe(eventType, handler, invoke, 2))
+	}
+	return ids
+}
@@ -0,0 +1,34 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeMany(t *testing.T) {
+	reset()
+	var received []any
+	ids := SubscribeMany(func(event any) {
+		received = append(received, event)
+	}, TypeOf[userCreatedEvent](), TypeOf[progressEvent](), TypeOf[orderEvent]())
+	assert.Len(t, ids, 3)
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(orderEvent{CustomerID: 1, Seq: 2}))
+	assert.Equal(t, []any{
+		userCreatedEvent{Name: "John Doe"},
+		progressEvent{Count: 1},
+		orderEvent{CustomerID: 1, Seq: 2},
+	}, received)
+
+	assert.True(t, Unsubscribe[progressEvent](ids[1]))
+	assert.Error(t, Publish(progressEvent{Count: 2}))
+	assert.Len(t, received, 3)
+}
+
+func TestTypeOf(t *testing.T) {
+	assert.Equal(t, "eventbus.progressEvent", TypeOf[progressEvent]().String())
+	assert.Equal(t, "error", TypeOf[error]().String())
+}
@@ -0,0 +1,37 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// SubscribeNamed registers a handler for a given type with a name describing the
+// handler. The name is reported by Subscriptions and can be used to remove
+// handlers in bulk with UnsubscribeWhere. Names don't need to be unique. The
+// return value is a subscription ID that can be used to unsubscribe the handler.
+func SubscribeNamed[T any](name string, handler Handler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+	entryByID(eventType, id).name = name
+	return id
+}
+
+// UnsubscribeWhere removes all handlers for the specified type for which pred
+// returns true. The predicate is invoked with the subscription ID and name of
+// each handler, where the name is empty for handlers not subscribed with
+// SubscribeNamed. Since subscription IDs are assigned in increasing order the ID
+// can also be used to remove handlers registered before or after another handler.
+// The predicate is invoked while holding the write lock and must not call back
+// into eventbus. The return value is the number of handlers removed.
+func UnsubscribeWhere[T any](pred func(id uint64, name string) bool) int {
+	mu.Lock()
+	defer unlockAndNotify()
+
+	return removeWhere(reflect.TypeOf(*new(T)), func(entry handlerEntry) bool {
+		return pred(entry.id, entry.name)
+	})
+}
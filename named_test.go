@@ -0,0 +1,38 @@
+package eventbus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeNamed(t *testing.T) {
+	reset()
+	h := HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {})
+	id := SubscribeNamed[userCreatedEvent]("audit", h)
+
+	assert.Equal(t, []SubscriptionInfo{{ID: id, Name: "audit"}}, Subscriptions[userCreatedEvent]())
+}
+
+func TestUnsubscribeWhere(t *testing.T) {
+	reset()
+	h := HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {})
+	SubscribeNamed[userCreatedEvent]("notify.email", h)
+	id1 := SubscribeNamed[userCreatedEvent]("audit", h)
+	SubscribeNamed[userCreatedEvent]("notify.sms", h)
+	id2 := Subscribe[userCreatedEvent](h)
+
+	removed := UnsubscribeWhere[userCreatedEvent](func(id uint64, name string) bool {
+		return strings.HasPrefix(name, "notify.")
+	})
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []SubscriptionInfo{{ID: id1, Name: "audit"}, {ID: id2}}, Subscriptions[userCreatedEvent]())
+	assert.Equal(t, uint64(2), Stats().Active)
+
+	removed = UnsubscribeWhere[userCreatedEvent](func(id uint64, name string) bool {
+		return id < id2
+	})
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, []SubscriptionInfo{{ID: id2}}, Subscriptions[userCreatedEvent]())
+}
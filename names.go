@@ -0,0 +1,31 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// TypeNamer returns the name of an event type used where the type is surfaced in
+// errors and audit entries, such as for use as a metric label or in logs.
+type TypeNamer func(eventType reflect.Type) string
+
+// ShortTypeName is the default TypeNamer. It returns the name of the type without
+// its package, such as "UserCreated" rather than "events.UserCreated". Types
+// without a name, such as pointers and slices, are named by their string form.
+func ShortTypeName(eventType reflect.Type) string {
+	if eventType == nil {
+		return "nil"
+	}
+	if name := eventType.Name(); name != "" {
+		return name
+	}
+	return eventType.String()
+}
+
+// typeName returns the name of the event type using the configured TypeNamer.
+// The caller must hold the read lock.
+func typeName(eventType reflect.Type) string {
+	if cfg.typeNamer != nil {
+		return cfg.typeNamer(eventType)
+	}
+	return ShortTypeName(eventType)
+}
@@ -0,0 +1,49 @@
+package eventbus
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortTypeName(t *testing.T) {
+	assert.Equal(t, "userCreatedEvent", ShortTypeName(reflect.TypeOf(userCreatedEvent{})))
+	assert.Equal(t, "*eventbus.userCreatedEvent", ShortTypeName(reflect.TypeOf(&userCreatedEvent{})))
+	assert.Equal(t, "[]int", ShortTypeName(reflect.TypeOf([]int{})))
+}
+
+func TestWithTypeNamer(t *testing.T) {
+	reset()
+	var errs []error
+	Configure(
+		WithTypeNamer(func(eventType reflect.Type) string {
+			return "events." + strings.ToLower(eventType.Name())
+		}),
+		WithErrorCallback(func(err error) {
+			errs = append(errs, err)
+		}),
+		WithSkipZeroValue(),
+		WithAuditLog(10),
+	)
+
+	err := Publish(progressEvent{Count: 1})
+	assert.EqualError(t, err, "no handler for event events.progressevent")
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	assert.NoError(t, Publish(progressEvent{}))
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrZeroValue)
+	assert.Contains(t, errs[0].Error(), "events.progressevent")
+
+	entries := AuditLog()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "events.progressevent", entries[0].TypeName)
+}
+
+func TestTypeName_Default(t *testing.T) {
+	reset()
+	err := Publish(progressEvent{Count: 1})
+	assert.EqualError(t, err, "no handler for event progressEvent")
+}
@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultDedupWindow is how long PublishOnce remembers a key when no window has
+// been configured with WithDedupWindow.
+const defaultDedupWindow = 5 * time.Minute
+
+// seenKeys holds the keys published with PublishOnce and when they expire.
+var seenKeys = seenSet{keys: make(map[string]time.Time)}
+
+// seenSet is a set of keys which are forgotten after they expire.
+type seenSet struct {
+	mu        sync.Mutex
+	keys      map[string]time.Time
+	nextPrune time.Time
+}
+
+// add records the key as seen until now plus window, returning false if the key
+// was already seen and hasn't expired.
+func (s *seenSet) add(key string, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := now()
+	if current.After(s.nextPrune) {
+		for k, expiresAt := range s.keys {
+			if current.After(expiresAt) {
+				delete(s.keys, k)
+			}
+		}
+		s.nextPrune = current.Add(window)
+	}
+
+	if expiresAt, ok := s.keys[key]; ok && !current.After(expiresAt) {
+		return false
+	}
+	s.keys[key] = current.Add(window)
+	return true
+}
+
+// PublishOnce publishes the event the same as Publish unless an event has
+// already been published with the same key within the deduplication window,
+// which defaults to five minutes and can be changed with WithDedupWindow. Keys
+// are shared by all event types and publishers, so a key should identify the
+// event across the whole bus, such as an idempotency key.
+//
+// The returned bool reports whether the event was dispatched. The key is recorded
+// as seen before the event is dispatched, so an event whose handlers returned an
+// error is not dispatched again, giving at most once delivery.
+func PublishOnce[T any](key string, event T) (bool, error) {
+	mu.RLock()
+	window := cfg.dedupWindow
+	mu.RUnlock()
+
+	if window <= 0 {
+		window = defaultDedupWindow
+	}
+	if !seenKeys.add(key, window) {
+		return false, nil
+	}
+	return true, Publish(event)
+}
@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishOnce(t *testing.T) {
+	reset()
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	h := new(userCreatedHandler)
+	h.On("OnEvent", event).Return()
+	Subscribe[userCreatedEvent](h)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	dispatched, err := PublishOnce("user-1", event)
+	assert.NoError(t, err)
+	assert.True(t, dispatched)
+
+	dispatched, err = PublishOnce("user-1", event)
+	assert.NoError(t, err)
+	assert.False(t, dispatched)
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+
+	// Keys are shared across event types
+	dispatched, err = PublishOnce("user-1", progressEvent{Count: 1})
+	assert.NoError(t, err)
+	assert.False(t, dispatched)
+
+	dispatched, err = PublishOnce("user-2", event)
+	assert.NoError(t, err)
+	assert.True(t, dispatched)
+	h.AssertNumberOfCalls(t, "OnEvent", 2)
+}
+
+func TestPublishOnce_Error(t *testing.T) {
+	reset()
+	dispatched, err := PublishOnce("key", progressEvent{Count: 1})
+	assert.Error(t, err)
+	assert.True(t, dispatched)
+
+	dispatched, err = PublishOnce("key", progressEvent{Count: 1})
+	assert.NoError(t, err)
+	assert.False(t, dispatched)
+}
+
+func TestWithDedupWindow(t *testing.T) {
+	reset()
+	Configure(WithDedupWindow(20 * time.Millisecond))
+	invoked := 0
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		invoked++
+	}))
+
+	dispatched, _ := PublishOnce("key", progressEvent{Count: 1})
+	assert.True(t, dispatched)
+	dispatched, _ = PublishOnce("key", progressEvent{Count: 1})
+	assert.False(t, dispatched)
+
+	time.Sleep(30 * time.Millisecond)
+	dispatched, _ = PublishOnce("key", progressEvent{Count: 1})
+	assert.True(t, dispatched)
+	assert.Equal(t, 2, invoked)
+}
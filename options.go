@@ -0,0 +1,518 @@
+package eventbus
+
+import (
+	"time"
+)
+
+// Option configures the behavior of eventbus.
+type Option func(cfg *config)
+
+type config struct {
+	initialCapacity     int
+	captureCallerInfo   bool
+	balanceStrategy     BalanceStrategy
+	errorCallback       func(err error)
+	strict              bool
+	pauseBufferSize     int
+	overflowPolicy      OverflowPolicy
+	retainLatest        bool
+	deepCopyAsync       bool
+	skipZeroValue       bool
+	blockOnRateLimit    bool
+	maxPublishDepth     int
+	lifecycleEvents     bool
+	dedupWindow         time.Duration
+	quarantineThreshold time.Duration
+	quarantineStrikes   int
+	maxAsyncGoroutines  int
+	asyncLimitPolicy    AsyncLimitPolicy
+	auditLogSize        int
+	typeNamer           TypeNamer
+	panicEvents         bool
+	maxHandlers         int
+	poolWorkers         int
+	poolQueueSize       int
+	metrics             MetricsCollector
+	consistentOrdering  bool
+	spillStore          SpillStore
+	submitFunc          func(task func())
+	appendHook          AppendHook
+	sequenceNumbers     bool
+	causalTracing       bool
+	schemaRegistry      SchemaRegistry
+	leakDetection       bool
+	clock               Clock
+	autoRemoveOnPanic   int
+	blockOnQueueFull    bool
+	bulkheads           bool
+	reentrancyGuard     bool
+	priorityInheritance bool
+}
+
+var cfg = config{}
+
+// Configure applies the provided options to eventbus. Options are applied in
+// the order provided and may be called multiple times, later calls overriding
+// values set by earlier calls. Configure is typically called once during
+// application startup prior to any subscriptions.
+func Configure(opts ...Option) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	configurePool()
+	configureClock()
+}
+
+// WithInitialCapacity preallocates the slice holding the handlers for an event
+// type with capacity n when the first handler for that type is subscribed. This
+// reduces reallocations when many handlers are registered for the same type.
+// A value of zero or less disables preallocation, which is the default.
+func WithInitialCapacity(n int) Option {
+	return func(cfg *config) {
+		cfg.initialCapacity = n
+	}
+}
+
+// WithCaptureCallerInfo enables capturing the file and line number a handler was
+// subscribed from, which is reported by Subscriptions. This is useful for
+// tracking down unexpected or leaked subscriptions but has a cost on every call
+// to Subscribe, so it is disabled by default.
+func WithCaptureCallerInfo() Option {
+	return func(cfg *config) {
+		cfg.captureCallerInfo = true
+	}
+}
+
+// WithBalanceStrategy sets the strategy PublishBalanced uses to select which
+// handler receives an event. The default strategy is RoundRobin.
+func WithBalanceStrategy(strategy BalanceStrategy) Option {
+	return func(cfg *config) {
+		cfg.balanceStrategy = strategy
+	}
+}
+
+// WithErrorCallback registers a callback that is invoked with errors that occur
+// when using MustPublish or MustPublishAsync, and errors returned by ErrorHandlers
+// invoked asynchronously. When an error callback is set the Must variants route
+// errors to the callback instead of panicking, unless strict mode is enabled with
+// WithStrictMode. Like a handler the callback is invoked without the bus locked,
+// so it may publish events and subscribe or unsubscribe handlers.
+func WithErrorCallback(fn func(err error)) Option {
+	return func(cfg *config) {
+		cfg.errorCallback = fn
+	}
+}
+
+// WithStrictMode forces MustPublish and MustPublishAsync to panic on error even
+// when an error callback has been registered with WithErrorCallback.
+func WithStrictMode() Option {
+	return func(cfg *config) {
+		cfg.strict = true
+	}
+}
+
+// WithPauseBuffer sets the maximum number of events buffered per event type
+// while the type is paused with Pause, and the policy applied when the buffer is
+// full. By default up to 1024 events are buffered and new events are rejected
+// with ErrBufferFull once the buffer is full.
+func WithPauseBuffer(size int, policy OverflowPolicy) Option {
+	return func(cfg *config) {
+		cfg.pauseBufferSize = size
+		cfg.overflowPolicy = policy
+	}
+}
+
+// WithLatestRetention enables retaining the most recently published event of
+// each type, which is delivered to handlers registered with SubscribeLatest when
+// they are subscribed.
+func WithLatestRetention() Option {
+	return func(cfg *config) {
+		cfg.retainLatest = true
+	}
+}
+
+// WithDeepCopyAsync enables cloning events that implement Cloneable for each
+// handler invoked asynchronously, so each handler receives an independent copy
+// of the event. This prevents data races when handlers mutate slices or maps
+// shared by the event, at the cost of a call to Clone, and typically one or more
+// allocations, per handler for every asynchronous publish. Events that don't
+// implement Cloneable are shared between handlers as is, and it is the
+// responsibility of the handlers not to mutate them.
+func WithDeepCopyAsync() Option {
+	return func(cfg *config) {
+		cfg.deepCopyAsync = true
+	}
+}
+
+// WithSkipZeroValue enables skipping events that are the zero value of their
+// type, such as an uninitialized struct, rather than dispatching them to
+// handlers. Skipped events are reported to the error callback with an error
+// wrapping ErrZeroValue. Note that events of types without any fields, such as
+// an empty struct, are always the zero value and are always skipped when this
+// option is enabled.
+func WithSkipZeroValue() Option {
+	return func(cfg *config) {
+		cfg.skipZeroValue = true
+	}
+}
+
+// WithBlockOnRateLimit makes publishing an event that exceeds the rate limit set
+// with SetRateLimit block until the event can be published, rather than
+// returning ErrRateLimited.
+func WithBlockOnRateLimit() Option {
+	return func(cfg *config) {
+		cfg.blockOnRateLimit = true
+	}
+}
+
+// WithMaxPublishDepth limits how deeply events can be published recursively, such
+// as a handler publishing an event whose handler in turn publishes another event,
+// on the same goroutine. When the limit is reached Publish returns
+// ErrMaxDepthExceeded rather than letting an accidental event loop overflow the
+// stack. Tracking the depth requires identifying the current goroutine, which
+// adds overhead to every call to Publish. A value of zero or less disables the
+// limit, which is the default.
+func WithMaxPublishDepth(n int) Option {
+	return func(cfg *config) {
+		cfg.maxPublishDepth = n
+	}
+}
+
+// WithLifecycleEvents enables publishing a SubscriptionAdded event whenever a
+// handler is subscribed and a SubscriptionRemoved event whenever a handler is
+// unsubscribed, allowing monitoring tools to react to changes in subscriptions.
+// The events are published synchronously after the change is made, once the
+// subscribing or unsubscribing function has released its lock. Changes to the
+// subscriptions of SubscriptionAdded and SubscriptionRemoved themselves are not
+// published.
+func WithLifecycleEvents() Option {
+	return func(cfg *config) {
+		cfg.lifecycleEvents = true
+	}
+}
+
+// WithDedupWindow sets how long PublishOnce remembers the key of a published
+// event. Publishing another event with the same key within the window is
+// skipped. Defaults to five minutes.
+func WithDedupWindow(window time.Duration) Option {
+	return func(cfg *config) {
+		cfg.dedupWindow = window
+	}
+}
+
+// WithQuarantine enables quarantining slow handlers so they can't hold up the
+// other handlers of an event type. Publish invokes handlers one after another,
+// so a slow handler delays every handler after it as well as the publisher. When
+// a handler takes longer than threshold to handle strikes events in a row it is
+// quarantined: from then on Publish queues events for the handler, which handles
+// them in order on its own goroutine, and returns without waiting for it. Errors
+// returned by a quarantined handler are passed to the error callback, and events
+// are dropped if the handler falls too far behind. A SubscriptionQuarantined
+// event is published when a handler is quarantined, and Subscriptions reports
+// which handlers are quarantined.
+//
+// Only handlers subscribed after WithQuarantine is configured are monitored.
+// Handlers are already invoked on their own goroutines by PublishAsync, so
+// quarantining only affects Publish.
+func WithQuarantine(threshold time.Duration, strikes int) Option {
+	return func(cfg *config) {
+		cfg.quarantineThreshold = threshold
+		cfg.quarantineStrikes = strikes
+	}
+}
+
+// WithMaxAsyncGoroutines caps the number of goroutines PublishAsync runs handlers
+// on at any one time, process wide. Once n handler goroutines are running the
+// policy decides what happens to further handlers: RunSynchronously, the default,
+// invokes them on the publishing goroutine before PublishAsync returns while
+// RejectAsync skips them and PublishAsync returns an error wrapping
+// ErrAsyncLimit. A value of zero or less removes the cap, which is the default.
+func WithMaxAsyncGoroutines(n int, policy AsyncLimitPolicy) Option {
+	return func(cfg *config) {
+		cfg.maxAsyncGoroutines = n
+		cfg.asyncLimitPolicy = policy
+	}
+}
+
+// WithAuditLog enables recording the last size events published in an audit log,
+// which can be retrieved with AuditLog and replayed with Replay. Once the log is
+// full the oldest entry is discarded for each new event. A size of zero or less
+// disables the audit log, which is the default.
+func WithAuditLog(size int) Option {
+	return func(cfg *config) {
+		cfg.auditLogSize = size
+	}
+}
+
+// WithTypeNamer sets the TypeNamer used to name event types in errors and audit
+// entries. This allows producing clean and stable names, such as for metric
+// labels. Defaults to ShortTypeName.
+func WithTypeNamer(namer TypeNamer) Option {
+	return func(cfg *config) {
+		cfg.typeNamer = namer
+	}
+}
+
+// WithPanicEvents enables recovering panics in handlers and publishing them as
+// HandlerPanic events, so a central monitor can observe and react to crashing
+// handlers. A handler that panics is treated as having failed with an error
+// wrapping ErrHandlerPanic, which Publish returns or, for PublishAsync, is passed
+// to the error callback. Without this option panics in handlers propagate as
+// normal.
+func WithPanicEvents() Option {
+	return func(cfg *config) {
+		cfg.panicEvents = true
+	}
+}
+
+// WithMaxHandlers limits the number of handlers that can be subscribed for each
+// event type, guarding against handlers leaking by being subscribed repeatedly.
+// Register returns an error wrapping ErrTooManyHandlers when the limit would be
+// exceeded, while the Subscribe functions, which can't return an error, panic
+// with it. A value of zero or less removes the limit, which is the default.
+func WithMaxHandlers(n int) Option {
+	return func(cfg *config) {
+		cfg.maxHandlers = n
+	}
+}
+
+// WithWorkerPool runs handlers invoked by PublishAsync on a fixed pool of workers
+// goroutines fed by a queue holding up to queueSize handler invocations, rather
+// than starting a goroutine for each handler. This bounds the resources async
+// publishing uses during bursts. Invocations are queued by the priority of their
+// handler, see SubscribePriority, and higher priorities are run first. When the
+// queue is full the most recently queued invocation of the lowest priority is
+// shed to make room for an invocation of higher priority, otherwise the new
+// invocation is rejected and PublishAsync returns an error wrapping
+// ErrQueueFull. ShedCounts reports how many invocations of each priority were
+// shed or rejected. A queueSize of zero or less uses a default of 1024, and
+// workers of zero or less disables the pool, which is the default.
+func WithWorkerPool(workers, queueSize int) Option {
+	return func(cfg *config) {
+		cfg.poolWorkers = workers
+		cfg.poolQueueSize = queueSize
+	}
+}
+
+// WithMetrics sets the MetricsCollector notified of events published and handlers
+// invoked. Event types are named by the TypeNamer configured with
+// WithTypeNamer. Metrics aren't collected by default.
+func WithMetrics(collector MetricsCollector) Option {
+	return func(cfg *config) {
+		cfg.metrics = collector
+	}
+}
+
+// WithConsistentOrdering makes PublishAsync invoke the handlers of each event one
+// after another on a single goroutine, in the same order Publish invokes them,
+// rather than on a goroutine each. This gives handlers which rely on running in
+// order the same guarantee for both Publish and PublishAsync, at the cost of
+// handlers of the same event no longer running in parallel. Events published
+// separately are still handled concurrently with each other. Escalated handlers,
+// see SubscribeEscalating, still run on the publishing goroutine.
+func WithConsistentOrdering() Option {
+	return func(cfg *config) {
+		cfg.consistentOrdering = true
+	}
+}
+
+// WithSpillover sets a SpillStore that the worker pool configured with
+// WithWorkerPool spills handler invocations to when its queue is full, rather
+// than shedding them, such as a store backed by a temporary file to survive
+// bursts too large to hold in memory. Once the queue has been drained the spilled
+// invocations are run in the order they were spilled. While invocations are
+// spilled any further invocations are spilled too so ordering is preserved.
+// Spilled invocations are run without the priority, context or expiry they were
+// published with, and invocations of fallback handlers and of handlers under
+// WithConsistentOrdering are never spilled.
+func WithSpillover(store SpillStore) Option {
+	return func(cfg *config) {
+		cfg.spillStore = store
+	}
+}
+
+// WithSubmitFunc sets the executor PublishAsync submits handler invocations to
+// rather than starting a goroutine for each, such as an existing ants pool or
+// errgroup, so the bus shares the application's concurrency limits. The executor
+// must eventually run every task it is given. The cap set with
+// WithMaxAsyncGoroutines still applies, counting tasks submitted but not yet
+// finished. The executor is not used when WithWorkerPool is configured. By
+// default each invocation runs on its own goroutine.
+//
+//	var g errgroup.Group
+//	eventbus.Configure(eventbus.WithSubmitFunc(func(task func()) {
+//		g.Go(func() error {
+//			task()
+//			return nil
+//		})
+//	}))
+func WithSubmitFunc(submit func(task func())) Option {
+	return func(cfg *config) {
+		cfg.submitFunc = submit
+	}
+}
+
+// WithAppendHook sets a hook invoked synchronously with every event published,
+// after filters have been applied but before the event is delivered to any
+// handler, buffered while paused or recorded in the audit log. This allows an
+// event store to be the source of truth for event sourced aggregates by
+// appending each event before it is handled. If the hook returns an error the
+// publish is aborted, no handler is invoked and the error is returned wrapped to
+// the publisher. Events republished by Replay are not appended again. Like a
+// handler the hook is invoked without the bus locked, so it may publish events
+// and subscribe or unsubscribe handlers.
+func WithAppendHook(hook AppendHook) Option {
+	return func(cfg *config) {
+		cfg.appendHook = hook
+	}
+}
+
+// WithSequenceNumbers stamps each event dispatched to the handlers registered for
+// its type with a number from a single sequence shared by every event type, so
+// handlers of several types can order and reconcile events across types. The
+// number is assigned when the event is dispatched, and numbered events are
+// dispatched one at a time, so numbers increase without gaps in the order events
+// are dispatched even when they are published concurrently, and ContextHandlers
+// can retrieve it from their context with Sequence. Events published by a
+// handler are numbered and dispatched before the event being handled reaches the
+// remaining handlers. PublishAsync queues the handlers of each event in order,
+// although they only run in order with a single worker, see WithWorkerPool.
+// Since a publisher waits while an event of another goroutine is dispatched,
+// handlers must not wait for events published by other goroutines, including
+// the goroutine SubscribeOnThread runs a handler on, and WithBlockOnQueueFull
+// must not be combined with it. Events aren't numbered by default.
+func WithSequenceNumbers() Option {
+	return func(cfg *config) {
+		cfg.sequenceNumbers = true
+	}
+}
+
+// WithCausalTracing assigns each event published an ID and links events
+// published from within a handler to the event the handler was handling, giving
+// a causal graph of events for debugging complex flows. The links are recorded
+// in the ID and CauseID of the entries of the audit log enabled with
+// WithAuditLog. Events published by a handler on the goroutine it was invoked on
+// are linked automatically, including by handlers invoked by PublishAsync.
+// Handlers publishing from other goroutines can link their events by publishing
+// with the context passed to a ContextHandler, see CauseID. Causal tracing is
+// disabled by default since identifying the publishing goroutine is relatively
+// expensive.
+func WithCausalTracing() Option {
+	return func(cfg *config) {
+		cfg.causalTracing = true
+	}
+}
+
+// WithSchemaRegistry sets the SchemaRegistry consulted when subscribing handlers
+// implementing Versioned, catching handlers built against an incompatible version
+// of an event type, such as in a plugin system, when they are registered rather
+// than when events fail to be handled. Register returns an error wrapping
+// ErrIncompatibleSchema and the error of the registry when a handler is
+// rejected, while the Subscribe functions, which can't return an error, panic
+// with it. Handlers that don't implement Versioned are not checked.
+func WithSchemaRegistry(registry SchemaRegistry) Option {
+	return func(cfg *config) {
+		cfg.schemaRegistry = registry
+	}
+}
+
+// WithLeakDetection reports handlers subscribed with SubscribeTracked whose
+// Subscription handle is garbage collected while they are still subscribed to the
+// error callback, with an error wrapping ErrSubscriptionLeaked. Combined with
+// WithCaptureCallerInfo the error names where the handler was subscribed. It is
+// a diagnostic intended for development: leaks are only noticed when the
+// garbage collector happens to run finalizers, which may be long after the
+// handle was lost or never, each handle carries a finalizer, and subscription IDs
+// returned by the other Subscribe functions can't be tracked. A handle that is
+// stored for the lifetime of the program is never reported. Leak detection only
+// applies to handlers subscribed while it is enabled.
+func WithLeakDetection() Option {
+	return func(cfg *config) {
+		cfg.leakDetection = true
+	}
+}
+
+// WithClock sets the Clock the time based features of the bus use, such as a fake
+// clock advanced manually by tests so TTLs, dedup windows, coalescing windows,
+// periodic publishes and rate limits can be exercised deterministically without
+// sleeping. Timers already started keep using the clock they were started with.
+// A nil clock restores the real clock, which is the default.
+func WithClock(c Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = c
+	}
+}
+
+// WithAutoRemoveOnPanic unsubscribes a handler once it has panicked threshold
+// times in a row, protecting the rest of the system from a handler that is likely
+// broken. Panics are recovered and published as HandlerPanic events as with
+// WithPanicEvents, and an invocation that doesn't panic resets the count. The
+// removal is reported to the error callback with an error wrapping
+// ErrHandlerRemoved, and with a SubscriptionRemoved event when WithLifecycleEvents
+// is enabled. A threshold of zero or less disables auto removal, which is the
+// default. It only applies to handlers subscribed while it is enabled.
+func WithAutoRemoveOnPanic(threshold int) Option {
+	return func(cfg *config) {
+		cfg.autoRemoveOnPanic = threshold
+	}
+}
+
+// WithBlockOnQueueFull makes PublishAsync block until the worker pool configured
+// with WithWorkerPool has room in its queue when it is full, applying
+// backpressure to publishers rather than shedding or rejecting invocations. How
+// long publishers are blocked is reported to the MetricsCollector set with
+// WithMetrics if it implements PublishBlockObserver. Invocations are still
+// spilled rather than blocking when WithSpillover is configured. Handlers running
+// on the pool which publish asynchronously can block the worker they run on, so
+// the pool needs enough workers to not wedge itself. It has no effect without a
+// worker pool.
+func WithBlockOnQueueFull() Option {
+	return func(cfg *config) {
+		cfg.blockOnQueueFull = true
+	}
+}
+
+// WithBulkheads gives the handlers of each event type a worker pool of their own,
+// with the number of workers and queue size configured with WithWorkerPool,
+// rather than sharing a single pool. A wedged or slow handler then only exhausts
+// the workers and queue of its own type, while events of other types continue to
+// be handled, see HealthByType. Shedding priorities apply within each type, and
+// invocations aren't spilled to the store configured with WithSpillover. It has
+// no effect without a worker pool.
+func WithBulkheads() Option {
+	return func(cfg *config) {
+		cfg.bulkheads = true
+	}
+}
+
+// WithReentrancyGuard makes Publish return an error wrapping ErrReentrantPublish
+// rather than dispatching an event published from a handler of an event of the
+// same type on the same goroutine, suppressing accidental recursion in chains of
+// events. Events of other types can still be published from handlers, and events
+// published with PublishAsync are dispatched on other goroutines so they aren't
+// guarded. Like WithMaxPublishDepth, guarding requires identifying the current
+// goroutine, which adds overhead to every call to Publish. The guard is disabled
+// by default.
+func WithReentrancyGuard() Option {
+	return func(cfg *config) {
+		cfg.reentrancyGuard = true
+	}
+}
+
+// WithPriorityInheritance makes events published from within a handler inherit
+// the priority of the handler, or the priority its event was published with using
+// PublishAsyncPriority, keeping causally related work at a consistent priority.
+// Asynchronous invocations of the handlers of an inherited event are queued for
+// the worker pool with the inherited priority, and ContextHandlers can observe it
+// with InheritedPriority. Handlers publishing from other goroutines can pass the
+// priority on by publishing with the context passed to a ContextHandler. Priority
+// inheritance is disabled by default since identifying the publishing goroutine is
+// relatively expensive.
+func WithPriorityInheritance() Option {
+	return func(cfg *config) {
+		cfg.priorityInheritance = true
+	}
+}
@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInitialCapacity(t *testing.T) {
+	reset()
+	Configure(WithInitialCapacity(64))
+
+	Subscribe[userCreatedEvent](new(userCreatedHandler))
+	assert.Equal(t, 64, cap(handlers[reflect.TypeOf(userCreatedEvent{})]))
+}
+
+func BenchmarkSubscribe(b *testing.B) {
+	b.Run("default", func(b *testing.B) {
+		benchmarkSubscribe(b)
+	})
+	b.Run("initial capacity", func(b *testing.B) {
+		benchmarkSubscribe(b, WithInitialCapacity(1000))
+	})
+}
+
+func benchmarkSubscribe(b *testing.B, opts ...Option) {
+	h := HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		reset()
+		Configure(opts...)
+		b.StartTimer()
+		for j := 0; j < 1000; j++ {
+			Subscribe[userCreatedEvent](h)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// dispatchOrdered invokes the handlers of the event type one after another in a
+// single asynchronous task, preserving the order Publish would invoke them in.
+// The caller must hold the read lock and pass the non-empty handlers it read for
+// the event type.
+func dispatchOrdered[T any](eventType reflect.Type, handler []handlerEntry, event T, d delivery) error {
+	callback, name := cfg.errorCallback, typeName(eventType)
+
+	type invocation struct {
+		invoke func(event any) error
+		event  T
+	}
+	var invocations []invocation
+	priority := 0
+	for _, h := range handler {
+		if d.skipEntry(h) {
+			continue
+		}
+		if e, ok := h.handler.(escalator); ok && e.escalated() {
+			var err error
+			unlocked(func() {
+				err = h.invoke(event)
+			})
+			if err != nil {
+				d.reportAsync(unlockedCallback(callback), err)
+			}
+			continue
+		}
+		if len(invocations) == 0 {
+			// Handlers are ordered by priority, so the first is the highest.
+			priority = h.priority
+		}
+		invocations = append(invocations, invocation{
+			invoke: d.asyncInvoker(eventType, name, h),
+			event:  copyAsync(event),
+		})
+	}
+	if len(invocations) == 0 {
+		return nil
+	}
+
+	err := d.submitAsync(eventType, priority, func() {
+		for _, i := range invocations {
+			if d.expired(name, callback) {
+				return
+			}
+			if err := i.invoke(i.event); err != nil {
+				d.reportAsync(callback, err)
+			}
+		}
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("%w: event %s", err, name)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package eventbus
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithConsistentOrdering(t *testing.T) {
+	reset()
+	Configure(WithConsistentOrdering())
+
+	var mu sync.Mutex
+	var order []string
+	goroutines := make(map[uint64]bool)
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("handler-%d", i)
+		delay := time.Duration(5-i) * time.Millisecond
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+			// Earlier handlers take longer, which would reorder them if they ran
+			// concurrently
+			if event.Count == 2 {
+				time.Sleep(delay)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			if event.Count == 2 {
+				goroutines[goroutineID()] = true
+			}
+		}))
+	}
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	syncOrder := order
+	order = nil
+
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 5
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, syncOrder, order)
+	assert.Len(t, goroutines, 1)
+	assert.False(t, goroutines[goroutineID()])
+}
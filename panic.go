@@ -0,0 +1,69 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// ErrHandlerPanic is wrapped by the error reported for a handler that panicked
+// while WithPanicEvents or WithAutoRemoveOnPanic is enabled.
+var ErrHandlerPanic = errors.New("eventbus: handler panicked")
+
+// HandlerPanic is published when a handler panics while WithPanicEvents or
+// WithAutoRemoveOnPanic is enabled.
+type HandlerPanic struct {
+	// EventType is the type of the event the handler panicked handling.
+	EventType reflect.Type
+	// SubscriptionID is the subscription ID of the handler that panicked.
+	SubscriptionID uint64
+	// Value is the value the handler panicked with.
+	Value any
+	// Stack is the stack trace of the goroutine the handler panicked on.
+	Stack []byte
+}
+
+var handlerPanicType = reflect.TypeOf(HandlerPanic{})
+
+// recoverPanic must be deferred. It recovers a panic in the handler with the
+// subscription ID, sets err to an error describing the panic and publishes a
+// HandlerPanic event. Panics in handlers of HandlerPanic are recovered but not
+// published, so a panicking monitor can't cause a loop of panics. If locked is
+// true the caller holds the read lock, which is released while the event is
+// published so handlers of HandlerPanic can subscribe and unsubscribe.
+func recoverPanic(eventType reflect.Type, id uint64, locked bool, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	*err = fmt.Errorf("%w: subscription %d: %v", ErrHandlerPanic, id, r)
+	if eventType == handlerPanicType {
+		return
+	}
+	event := HandlerPanic{
+		EventType:      eventType,
+		SubscriptionID: id,
+		Value:          r,
+		Stack:          debug.Stack(),
+	}
+	publishPanic := func() {
+		// Nobody listening for panic events isn't an error.
+		_ = Publish(event)
+	}
+	if locked {
+		unlocked(publishPanic)
+		return
+	}
+	publishPanic()
+}
+
+// recovering wraps invoke, which is invoked without holding the lock, so panics
+// are recovered and published by recoverPanic.
+func recovering(eventType reflect.Type, id uint64, invoke func(event any) error) func(event any) error {
+	return func(event any) (err error) {
+		defer recoverPanic(eventType, id, false, &err)
+		return invoke(event)
+	}
+}
@@ -0,0 +1,109 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validationError struct {
+	Field string
+}
+
+func (e validationError) Error() string {
+	return "invalid " + e.Field
+}
+
+func TestWithPanicEvents(t *testing.T) {
+	reset()
+	Configure(WithPanicEvents())
+
+	panics := make(chan HandlerPanic, 2)
+	Subscribe[HandlerPanic](HandlerFunc[HandlerPanic](func(event HandlerPanic) {
+		panics <- event
+	}))
+	id := Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		panic(validationError{Field: "email"})
+	}))
+	invoked := false
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		invoked = true
+	}))
+
+	err := Publish(userCreatedEvent{Name: "John Doe"})
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.True(t, invoked)
+
+	event := <-panics
+	assert.Equal(t, reflect.TypeOf(userCreatedEvent{}), event.EventType)
+	assert.Equal(t, id, event.SubscriptionID)
+	var verr validationError
+	assert.True(t, errors.As(event.Value.(error), &verr))
+	assert.Equal(t, "email", verr.Field)
+	assert.Contains(t, string(event.Stack), "panic_test.go")
+
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	select {
+	case event = <-panics:
+		assert.Equal(t, id, event.SubscriptionID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for panic event")
+	}
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithPanicEvents_SubscribeFromHandler(t *testing.T) {
+	reset()
+	Configure(WithPanicEvents())
+
+	Subscribe[HandlerPanic](HandlerFunc[HandlerPanic](func(HandlerPanic) {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+	}))
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		panic("boom")
+	}))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- Publish(userCreatedEvent{Name: "John Doe"})
+	}()
+	select {
+	case err := <-published:
+		assert.ErrorIs(t, err, ErrHandlerPanic)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked publishing the HandlerPanic event")
+	}
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+}
+
+func TestWithPanicEvents_NoLoop(t *testing.T) {
+	reset()
+	Configure(WithPanicEvents())
+
+	invoked := 0
+	Subscribe[HandlerPanic](HandlerFunc[HandlerPanic](func(HandlerPanic) {
+		invoked++
+		panic("monitor failed")
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		panic("boom")
+	}))
+
+	assert.ErrorIs(t, Publish(progressEvent{Count: 1}), ErrHandlerPanic)
+	assert.Equal(t, 1, invoked)
+}
+
+func TestWithPanicEvents_Disabled(t *testing.T) {
+	reset()
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		panic("boom")
+	}))
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = Publish(progressEvent{Count: 1})
+	})
+}
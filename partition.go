@@ -0,0 +1,84 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// partitionBufferSize is the number of events each partition buffers before
+// publishing blocks waiting on the partition's handler.
+const partitionBufferSize = 64
+
+// stopper is implemented by handlers that own resources, such as goroutines,
+// which need to be released when the handler is unsubscribed.
+type stopper interface {
+	stop()
+}
+
+// partitionedHandler routes events to one of a pool of handlers, each with its
+// own goroutine, based on the partition key of the event.
+type partitionedHandler[T any] struct {
+	partitions []chan T
+	key        func(event T) uint64
+	done       chan struct{}
+}
+
+func (p *partitionedHandler[T]) OnEvent(event T) {
+	partition := p.partitions[p.key(event)%uint64(len(p.partitions))]
+	select {
+	case partition <- event:
+	case <-p.done:
+	}
+}
+
+func (p *partitionedHandler[T]) stop() {
+	close(p.done)
+}
+
+func (p *partitionedHandler[T]) run(handler Handler[T], partition chan T) {
+	for {
+		select {
+		case event := <-partition:
+			handler.OnEvent(event)
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// SubscribePartitioned registers a pool of handlers for a given type where each
+// event is delivered to exactly one handler selected by the partition key of the
+// event. Events with the same key are always delivered to the same handler, and
+// each handler processes its events sequentially on its own dedicated goroutine.
+// This guarantees ordering of events with the same key while events with
+// different keys are processed in parallel. SubscribePartitioned panics if no
+// handlers are provided. The return value is a subscription ID that can be used
+// to unsubscribe the pool, which also stops the goroutines of the pool. Events
+// still buffered for a partition when the pool is unsubscribed are discarded.
+func SubscribePartitioned[T any](handlers []Handler[T], key func(event T) uint64) uint64 {
+	if len(handlers) == 0 {
+		panic("eventbus: SubscribePartitioned requires at least one handler")
+	}
+	for _, h := range handlers {
+		mustNotBeNil(h)
+	}
+
+	p := &partitionedHandler[T]{
+		partitions: make([]chan T, len(handlers)),
+		key:        key,
+		done:       make(chan struct{}),
+	}
+	for i := range handlers {
+		p.partitions[i] = make(chan T, partitionBufferSize)
+	}
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	// The goroutines are started once subscribing succeeded, so none are leaked
+	// if it panics. Events dispatched before then wait in the partitions.
+	id := subscribe(reflect.TypeOf(*new(T)), p, handlerInvoker[T](p), 2)
+	for i, h := range handlers {
+		go p.run(h, p.partitions[i])
+	}
+	return id
+}
@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderEvent struct {
+	CustomerID uint64
+	Seq        int
+}
+
+func TestSubscribePartitioned(t *testing.T) {
+	reset()
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		routing = make(map[uint64]map[int]bool)
+		ordered = make(map[uint64][]int)
+	)
+	pool := make([]Handler[orderEvent], 4)
+	for i := range pool {
+		i := i
+		pool[i] = HandlerFunc[orderEvent](func(event orderEvent) {
+			defer wg.Done()
+			lock.Lock()
+			defer lock.Unlock()
+			if routing[event.CustomerID] == nil {
+				routing[event.CustomerID] = make(map[int]bool)
+			}
+			routing[event.CustomerID][i] = true
+			ordered[event.CustomerID] = append(ordered[event.CustomerID], event.Seq)
+		})
+	}
+	id := SubscribePartitioned[orderEvent](pool, func(event orderEvent) uint64 {
+		return event.CustomerID
+	})
+
+	for seq := 0; seq < 10; seq++ {
+		wg.Add(2)
+		assert.NoError(t, Publish(orderEvent{CustomerID: 1, Seq: seq}))
+		assert.NoError(t, Publish(orderEvent{CustomerID: 2, Seq: seq}))
+	}
+	wg.Wait()
+
+	assert.Len(t, routing[1], 1)
+	assert.Len(t, routing[2], 1)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ordered[1])
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ordered[2])
+	assert.True(t, Unsubscribe[orderEvent](id))
+}
+
+func TestSubscribePartitioned_NoHandlers(t *testing.T) {
+	reset()
+	assert.Panics(t, func() {
+		SubscribePartitioned[orderEvent](nil, func(event orderEvent) uint64 {
+			return event.CustomerID
+		})
+	})
+}
+
+func TestSubscribePartitioned_SubscribePanics(t *testing.T) {
+	reset()
+	Configure(WithMaxHandlers(1))
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+
+	// No goroutine of the pool is left running when subscribing panics
+	goroutines := runtime.NumGoroutine()
+	assert.Panics(t, func() {
+		SubscribePartitioned([]Handler[orderEvent]{
+			HandlerFunc[orderEvent](func(orderEvent) {}),
+			HandlerFunc[orderEvent](func(orderEvent) {}),
+		}, func(event orderEvent) uint64 {
+			return event.CustomerID
+		})
+	})
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
@@ -0,0 +1,154 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// ErrBufferFull is returned when publishing an event of a paused type whose
+// buffer is full and the overflow policy is DropNewest.
+var ErrBufferFull = errors.New("eventbus: pause buffer is full")
+
+const defaultPauseBufferSize = 1024
+
+// OverflowPolicy determines what happens when an event is published for a paused
+// type whose buffer is already full.
+type OverflowPolicy int
+
+const (
+	// DropNewest rejects the event being published with ErrBufferFull.
+	DropNewest OverflowPolicy = iota
+	// DropOldest discards the oldest buffered event to make room for the event
+	// being published.
+	DropOldest
+)
+
+type pausedEvent struct {
+	event    any
+	async    bool
+	delivery delivery
+}
+
+type pauseState struct {
+	mu       sync.Mutex
+	paused   bool
+	resuming bool
+	events   []pausedEvent
+}
+
+var pauses = make(map[reflect.Type]*pauseState)
+
+// Pause suspends delivery of events of the given type. While paused, events
+// published with Publish or PublishAsync are buffered rather than dispatched to
+// handlers, and are delivered in the order they were published once Resume is
+// called. The buffer size and overflow policy are configured with
+// WithPauseBuffer. Calling Pause on a type that is already paused has no effect.
+func Pause[T any]() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	eventType := reflect.TypeOf(*new(T))
+	state, ok := pauses[eventType]
+	if !ok {
+		state = &pauseState{}
+		pauses[eventType] = state
+	}
+
+	state.mu.Lock()
+	state.paused = true
+	state.mu.Unlock()
+}
+
+// Resume delivers the events buffered while the given type was paused in the
+// order they were published, then resumes live delivery. Events published while
+// Resume is delivering the buffered events are delivered after them, preserving
+// publish order. Events buffered from Publish are delivered synchronously, while
+// events buffered from PublishAsync are delivered asynchronously. Any errors
+// from delivering the buffered events are joined and returned. Calling Resume on
+// a type that isn't paused has no effect.
+func Resume[T any]() error {
+	mu.RLock()
+	state, ok := pauses[reflect.TypeOf(*new(T))]
+	mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	state.mu.Lock()
+	if !state.paused || state.resuming {
+		state.mu.Unlock()
+		return nil
+	}
+	state.resuming = true
+	state.mu.Unlock()
+
+	var errs []error
+	for {
+		state.mu.Lock()
+		if len(state.events) == 0 {
+			state.paused = false
+			state.resuming = false
+			state.mu.Unlock()
+			return errors.Join(errs...)
+		}
+		batch := state.events
+		state.events = nil
+		state.mu.Unlock()
+
+		for _, e := range batch {
+			if err := deliverPaused[T](e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+}
+
+func deliverPaused[T any](e pausedEvent) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	event := e.event.(T)
+	eventType := reflect.TypeOf(event)
+	if e.async {
+		if err := e.delivery.ctx.Err(); err != nil {
+			return err
+		}
+		return dispatchAsync(eventType, event, e.delivery)
+	}
+	return dispatch(eventType, event, e.delivery)
+}
+
+// bufferIfPaused buffers the event if the event type is paused, returning true
+// if the event was buffered or rejected. The publisher has returned by the time a
+// buffered event is delivered, so the receipt, results and batch of the delivery
+// aren't buffered with it: the receipt records no handlers, no results are
+// collected and the batch doesn't wait for the event. The caller must hold the
+// read lock.
+func bufferIfPaused[T any](eventType reflect.Type, event T, async bool, d delivery) (bool, error) {
+	state, ok := pauses[eventType]
+	if !ok {
+		return false, nil
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if !state.paused {
+		return false, nil
+	}
+
+	size := cfg.pauseBufferSize
+	if size <= 0 {
+		size = defaultPauseBufferSize
+	}
+	if len(state.events) >= size {
+		if cfg.overflowPolicy != DropOldest {
+			return true, ErrBufferFull
+		}
+		state.events = state.events[1:]
+	}
+	d.receipt, d.onResult, d.batch = nil, nil, nil
+	state.events = append(state.events, pausedEvent{event: event, async: async, delivery: d})
+	return true, nil
+}
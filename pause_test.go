@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseResume(t *testing.T) {
+	reset()
+	var received []progressEvent
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event)
+	}))
+
+	Pause[progressEvent]()
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.NoError(t, Publish(progressEvent{Count: 3}))
+	assert.Empty(t, received)
+
+	assert.NoError(t, Resume[progressEvent]())
+	assert.Equal(t, []progressEvent{{Count: 1}, {Count: 2}, {Count: 3}}, received)
+
+	assert.NoError(t, Publish(progressEvent{Count: 4}))
+	assert.Equal(t, []progressEvent{{Count: 1}, {Count: 2}, {Count: 3}, {Count: 4}}, received)
+}
+
+func TestPause_DoesNotAffectOtherTypes(t *testing.T) {
+	reset()
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}).Return()
+	Subscribe[userCreatedEvent](h)
+
+	Pause[progressEvent]()
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestPause_Overflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverflowPolicy
+		err      error
+		expected []progressEvent
+	}{
+		{
+			name:     "drop newest",
+			policy:   DropNewest,
+			err:      ErrBufferFull,
+			expected: []progressEvent{{Count: 1}, {Count: 2}},
+		},
+		{
+			name:     "drop oldest",
+			policy:   DropOldest,
+			err:      nil,
+			expected: []progressEvent{{Count: 2}, {Count: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			Configure(WithPauseBuffer(2, tt.policy))
+			var received []progressEvent
+			Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+				received = append(received, event)
+			}))
+
+			Pause[progressEvent]()
+			assert.NoError(t, Publish(progressEvent{Count: 1}))
+			assert.NoError(t, Publish(progressEvent{Count: 2}))
+			assert.Equal(t, tt.err, Publish(progressEvent{Count: 3}))
+
+			assert.NoError(t, Resume[progressEvent]())
+			assert.Equal(t, tt.expected, received)
+		})
+	}
+}
+
+func TestPause_DropsPublisherState(t *testing.T) {
+	reset()
+	errFailed := errors.New("failed")
+	handled := make(chan struct{}, 1)
+	SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(progressEvent) error {
+		handled <- struct{}{}
+		return errFailed
+	}))
+
+	Pause[progressEvent]()
+	receipt, err := PublishWithReceipt(progressEvent{Count: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, receipt.Acked)
+	b := NewAsyncBatch()
+	assert.NoError(t, b.PublishAsync(progressEvent{Count: 2}))
+	assert.NoError(t, b.Wait(context.Background()))
+
+	// Delivering the buffered events doesn't touch the receipt or the batch
+	assert.ErrorIs(t, Resume[progressEvent](), errFailed)
+	<-handled
+	<-handled
+	assert.Empty(t, receipt.Failed)
+	assert.NoError(t, b.Wait(context.Background()))
+}
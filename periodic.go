@@ -0,0 +1,93 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	periodicMu = sync.Mutex{}
+	periodics  = make(map[*periodic]struct{})
+)
+
+// periodic publishes an event on each tick of a ticker until it is stopped.
+type periodic struct {
+	done      chan struct{}
+	exited    chan struct{}
+	once      sync.Once
+	goroutine atomic.Uint64
+}
+
+// stop stops publishing and waits for a publish in progress to finish, unless it
+// is called by a handler of the periodic event on the publishing goroutine.
+func (p *periodic) stop() {
+	p.once.Do(func() {
+		close(p.done)
+
+		periodicMu.Lock()
+		delete(periodics, p)
+		periodicMu.Unlock()
+	})
+	if goroutineID() != p.goroutine.Load() {
+		<-p.exited
+	}
+}
+
+// PublishEvery publishes the event every interval until the returned stop
+// function is called, which is convenient for heartbeats and other periodic
+// events. The event is published with Publish from a goroutine owned by the bus,
+// and errors returned by Publish are passed to the error callback. Stop may be
+// called more than once. CloseHandlers stops every periodic publish that hasn't
+// been stopped. Once stop returns the event won't be published again. Handlers
+// of the event may call stop themselves. PublishEvery panics if interval is not
+// greater than zero.
+func PublishEvery[T any](event T, interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		panic("eventbus: PublishEvery requires a positive interval")
+	}
+
+	p := &periodic{done: make(chan struct{}), exited: make(chan struct{})}
+	periodicMu.Lock()
+	periodics[p] = struct{}{}
+	periodicMu.Unlock()
+
+	go func() {
+		defer close(p.exited)
+		p.goroutine.Store(goroutineID())
+		timer := currentClock().NewTimer(interval)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C():
+				timer.Reset(interval)
+				if err := Publish(event); err != nil {
+					mu.RLock()
+					callback := cfg.errorCallback
+					mu.RUnlock()
+					if callback != nil {
+						callback(err)
+					}
+				}
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return p.stop
+}
+
+// stopPeriodics stops every periodic publish started by PublishEvery.
+func stopPeriodics() {
+	periodicMu.Lock()
+	running := make([]*periodic, 0, len(periodics))
+	for p := range periodics {
+		running = append(running, p)
+	}
+	periodicMu.Unlock()
+
+	for _, p := range running {
+		p.stop()
+	}
+}
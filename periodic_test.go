@@ -0,0 +1,77 @@
+package eventbus
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishEvery(t *testing.T) {
+	reset()
+	var published atomic.Int32
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		published.Add(1)
+	}))
+
+	stop := PublishEvery(progressEvent{Count: 1}, 10*time.Millisecond)
+	time.Sleep(55 * time.Millisecond)
+	stop()
+	stop()
+
+	count := published.Load()
+	assert.GreaterOrEqual(t, count, int32(3))
+	assert.LessOrEqual(t, count, int32(6))
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, count, published.Load())
+}
+
+func TestPublishEvery_CloseHandlers(t *testing.T) {
+	reset()
+	var errs atomic.Int32
+	Configure(WithErrorCallback(func(error) {
+		errs.Add(1)
+	}))
+
+	PublishEvery(progressEvent{Count: 1}, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return errs.Load() > 0
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, CloseHandlers(context.Background()))
+	assert.Empty(t, periodics)
+}
+
+func TestPublishEvery_InvalidInterval(t *testing.T) {
+	assert.Panics(t, func() {
+		PublishEvery(progressEvent{Count: 1}, 0)
+	})
+}
+
+func TestPublishEvery_StopFromHandler(t *testing.T) {
+	reset()
+	var published atomic.Int32
+	var stop func()
+	stopped := make(chan struct{})
+	ready := make(chan struct{})
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		<-ready
+		if published.Add(1) == 3 {
+			stop()
+			close(stopped)
+		}
+	}))
+
+	stop = PublishEvery(progressEvent{Count: 1}, 5*time.Millisecond)
+	close(ready)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for handler to stop publishing")
+	}
+	stop()
+	assert.Equal(t, int32(3), published.Load())
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// pipeHandler publishes the result of transforming each event it receives.
+type pipeHandler[In, Out any] struct {
+	fn func(event In) (Out, error)
+}
+
+func (p *pipeHandler[In, Out]) OnEvent(event In) error {
+	out, err := p.fn(event)
+	if err != nil {
+		return err
+	}
+	return Publish(out)
+}
+
+func (*pipeHandler[In, Out]) pipesTo() reflect.Type {
+	return TypeOf[Out]()
+}
+
+func (*pipeHandler[In, Out]) DispatchPreference() DispatchMode {
+	return DispatchAsync
+}
+
+// SubscribePipe registers fn as an asynchronous handler of events of type In and
+// publishes the event of type Out it returns with Publish, building a pipeline
+// of stages declaratively without each stage publishing its result itself. Fn is
+// invoked asynchronously even for events published with Publish, the same as a
+// handler preferring DispatchAsync. Errors returned by fn, in which case nothing
+// is published, and errors publishing the result are passed to the error
+// callback. The return value is a subscription ID that can be used to unsubscribe
+// the pipe with Unsubscribe[In].
+func SubscribePipe[In, Out any](fn func(event In) (Out, error)) uint64 {
+	mustNotBeNil(fn)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	p := &pipeHandler[In, Out]{fn: fn}
+	return subscribe(reflect.TypeOf(*new(In)), p, errorHandlerInvoker[In](p), 2)
+}
@@ -0,0 +1,47 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribePipe(t *testing.T) {
+	reset()
+	errInvalid := errors.New("invalid order")
+	reported := make(chan error, 1)
+	Configure(WithErrorCallback(func(err error) {
+		reported <- err
+	}))
+
+	SubscribePipe(func(event orderEvent) (progressEvent, error) {
+		if event.Seq < 0 {
+			return progressEvent{}, errInvalid
+		}
+		return progressEvent{Count: event.Seq * 10}, nil
+	})
+	received := make(chan progressEvent, 1)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received <- event
+	}))
+
+	assert.NoError(t, Publish(orderEvent{Seq: 4}))
+	select {
+	case event := <-received:
+		assert.Equal(t, progressEvent{Count: 40}, event)
+	case <-time.After(time.Second):
+		t.Fatal("piped event was not published")
+	}
+
+	// Errors are passed to the error callback and nothing is published
+	assert.NoError(t, Publish(orderEvent{Seq: -1}))
+	select {
+	case err := <-reported:
+		assert.ErrorIs(t, err, errInvalid)
+	case <-time.After(time.Second):
+		t.Fatal("pipe error was not reported")
+	}
+	assert.Empty(t, received)
+}
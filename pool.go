@@ -0,0 +1,337 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultQueueSize is the number of handler invocations the worker pool queues
+// when no queue size is given to WithWorkerPool.
+const defaultQueueSize = 1024
+
+// ErrQueueFull is returned by PublishAsync when a handler invocation can't be
+// queued for the worker pool because the queue is full of invocations of equal
+// or higher priority.
+var ErrQueueFull = errors.New("eventbus: async queue is full")
+
+// errNoRoom is returned by the worker pool when its queue is full and it blocks
+// publishers rather than shedding, see WithBlockOnQueueFull.
+var errNoRoom = errors.New("eventbus: no room in async queue")
+
+// pool is the worker pool handlers invoked by PublishAsync run on when
+// WithWorkerPool is configured. It is nil when handlers run on their own
+// goroutines.
+var pool *workerPool
+
+// lane is the FIFO queue of tasks of a single priority.
+type lane struct {
+	priority int
+	tasks    []queuedTask
+}
+
+// queuedTask is a task queued for the worker pool. Shed is called instead of run
+// if the task is shed.
+type queuedTask struct {
+	run  func()
+	shed func()
+}
+
+// workerPool runs queued tasks on a fixed number of goroutines. Tasks are queued
+// in lanes by priority, higher priorities being run first, and tasks within a
+// lane are run in the order they were queued. When the queue is full the newest
+// task of the lowest priority is shed to make room for a task of higher priority.
+type workerPool struct {
+	workers  int
+	capacity int
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	room    *sync.Cond
+	lanes   []lane // sorted by priority, highest first
+	queued  int
+	shed    map[int]uint64
+	closed  bool
+	pending *inflightCounter
+	store   SpillStore
+	spilled int
+	block   bool
+	busy    atomic.Int32
+}
+
+func newWorkerPool(workers, capacity int) *workerPool {
+	p := &workerPool{
+		workers:  workers,
+		capacity: capacity,
+		shed:     make(map[int]uint64),
+		pending:  &inflightCounter{},
+	}
+	p.cond = sync.NewCond(&p.mu)
+	p.room = sync.NewCond(&p.mu)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// submit queues the task with the given priority, shedding a queued task of lower
+// priority if the queue is full. ErrQueueFull is returned if the queue is full
+// and there is no task of lower priority to shed. If the task is a handler
+// invocation described by inv and a spill store is configured, the invocation is
+// spilled to the store rather than shedding when the queue is full. Once
+// invocations have been spilled further invocations are spilled too until the
+// store has been drained, so they are run in the order they were submitted. If
+// the pool blocks publishers errNoRoom is returned rather than shedding when the
+// queue is full.
+func (p *workerPool) submit(priority int, task queuedTask, inv *SpilledInvocation) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if inv != nil && p.store != nil && (p.spilled > 0 || p.queued >= p.capacity) {
+		if err := p.store.Push(*inv); err != nil {
+			return fmt.Errorf("eventbus: spilling handler invocation: %w", err)
+		}
+		p.spilled++
+		p.pending.tryAdd(0)
+		p.cond.Signal()
+		return nil
+	}
+
+	if p.queued >= p.capacity {
+		if p.block {
+			return errNoRoom
+		}
+		lowest := &p.lanes[len(p.lanes)-1]
+		if lowest.priority >= priority {
+			p.shed[priority]++
+			return ErrQueueFull
+		}
+		lowest.tasks[len(lowest.tasks)-1].shed()
+		lowest.tasks = lowest.tasks[:len(lowest.tasks)-1]
+		p.shed[lowest.priority]++
+		p.queued--
+		p.pending.done()
+		if len(lowest.tasks) == 0 {
+			p.lanes = p.lanes[:len(p.lanes)-1]
+		}
+	}
+
+	i := 0
+	for i < len(p.lanes) && p.lanes[i].priority > priority {
+		i++
+	}
+	if i == len(p.lanes) || p.lanes[i].priority != priority {
+		p.lanes = append(p.lanes, lane{})
+		copy(p.lanes[i+1:], p.lanes[i:])
+		p.lanes[i] = lane{priority: priority}
+	}
+	p.lanes[i].tasks = append(p.lanes[i].tasks, task)
+	p.queued++
+	p.pending.tryAdd(0)
+	p.cond.Signal()
+	return nil
+}
+
+// next removes and returns the next task to run, blocking until there is one.
+// Tasks in the queue are run before spilled invocations, which were submitted
+// after them. It returns false once the pool has been stopped and its queue and
+// spill store are empty.
+func (p *workerPool) next() (func(), bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.queued == 0 && p.spilled == 0 {
+		if p.closed {
+			return nil, false
+		}
+		p.cond.Wait()
+	}
+
+	if p.queued == 0 {
+		p.spilled--
+		inv, err := p.store.Pop()
+		return func() {
+			runSpilled(inv, err)
+		}, true
+	}
+
+	highest := &p.lanes[0]
+	task := highest.tasks[0]
+	highest.tasks[0] = queuedTask{}
+	highest.tasks = highest.tasks[1:]
+	if len(highest.tasks) == 0 {
+		p.lanes = p.lanes[1:]
+	}
+	p.queued--
+	p.room.Broadcast()
+	return task.run, true
+}
+
+// waitForRoom blocks until the queue has room for a task or the pool has been
+// stopped.
+func (p *workerPool) waitForRoom() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.queued >= p.capacity && !p.closed {
+		p.room.Wait()
+	}
+}
+
+// saturated reports whether every worker is running a task.
+func (p *workerPool) saturated() bool {
+	return int(p.busy.Load()) >= p.workers
+}
+
+func (p *workerPool) setBlocking(block bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.block = block
+}
+
+func (p *workerPool) work() {
+	for {
+		task, ok := p.next()
+		if !ok {
+			return
+		}
+		p.busy.Add(1)
+		task()
+		p.busy.Add(-1)
+		p.pending.done()
+	}
+}
+
+// stop stops the workers once they have run the tasks already queued.
+func (p *workerPool) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	p.cond.Broadcast()
+	p.room.Broadcast()
+}
+
+func (p *workerPool) shedCounts() map[int]uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counts := make(map[int]uint64, len(p.shed))
+	for priority, n := range p.shed {
+		counts[priority] = n
+	}
+	return counts
+}
+
+// configurePool starts, replaces or stops the worker pool to match the worker
+// pool configuration. The caller must hold the write lock.
+func configurePool() {
+	size := cfg.poolQueueSize
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	if pool == nil || pool.workers != cfg.poolWorkers || pool.capacity != size {
+		if pool != nil {
+			pool.stop()
+			pool = nil
+		}
+		if cfg.poolWorkers > 0 {
+			pool = newWorkerPool(cfg.poolWorkers, size)
+		}
+	}
+	if pool != nil {
+		pool.setStore(cfg.spillStore)
+		pool.setBlocking(cfg.blockOnQueueFull)
+	}
+	configureBulkheads()
+}
+
+// submitAsync runs an asynchronous handler invocation of the delivery for an
+// event of the given type on the worker pool if one is configured, otherwise on
+// its own goroutine, queued with the priority of the delivery if it has one,
+// otherwise the given priority of the handler. The invocation is counted as
+// pending until it starts, see QueueDepth, and as in flight for the event type
+// and the batch of the delivery until it returns, see DrainType and AsyncBatch.
+// Invocations of a batch are never spilled since the batch would wait for them
+// forever. The caller must hold the read lock.
+func (d delivery) submitAsync(eventType reflect.Type, handlerPriority int, task func(), inv *SpilledInvocation) error {
+	counter, batch := typeInflight(eventType), d.batch
+	counter.tryAdd(0)
+	batch.add()
+	enqueued(eventType)
+	run := func() {
+		dequeued(eventType)
+		defer counter.done()
+		defer batch.done()
+		task()
+	}
+	shed := func() {
+		dequeued(eventType)
+		counter.done()
+		batch.done()
+	}
+	if batch != nil {
+		inv = nil
+	}
+
+	var err error
+	if pool != nil {
+		err = submitPool(eventType, d.queuePriority(handlerPriority), queuedTask{run: run, shed: shed}, inv)
+	} else {
+		err = goAsync(run)
+	}
+	if err != nil {
+		shed()
+	}
+	return err
+}
+
+// submitPool queues the task for the worker pool. With WithBlockOnQueueFull it
+// waits for room when the queue is full, releasing the read lock while it waits,
+// and reports how long it was blocked to the metrics collector. The caller must
+// hold the read lock.
+func submitPool(eventType reflect.Type, priority int, task queuedTask, inv *SpilledInvocation) error {
+	p := poolFor(eventType)
+	if !cfg.blockOnQueueFull {
+		return p.submit(priority, task, inv)
+	}
+
+	var blocked time.Duration
+	var err error
+	for {
+		if err = p.submit(priority, task, inv); err != errNoRoom {
+			break
+		}
+		start := time.Now()
+		unlocked(p.waitForRoom)
+		blocked += time.Since(start)
+		if p = poolFor(eventType); p == nil {
+			// The pool was disabled while waiting
+			err = goAsync(task.run)
+			break
+		}
+	}
+	recordBlocked(eventType, blocked)
+	return err
+}
+
+// ShedCounts returns the number of handler invocations the worker pool has shed,
+// or rejected because its queue was full, keyed by the priority of the handler.
+// With WithBulkheads the counts of the pools of every event type are summed. It
+// is empty when no worker pool is configured.
+func ShedCounts() map[int]uint64 {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	counts := make(map[int]uint64)
+	for _, p := range workerPools() {
+		for priority, n := range p.shedCounts() {
+			counts[priority] += n
+		}
+	}
+	return counts
+}
@@ -0,0 +1,126 @@
+package eventbus
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithWorkerPool(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(2, 16))
+
+	var mu sync.Mutex
+	goroutines := make(map[uint64]bool)
+	var wg sync.WaitGroup
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		defer wg.Done()
+		mu.Lock()
+		defer mu.Unlock()
+		goroutines[goroutineID()] = true
+	}))
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, len(goroutines), 2)
+	assert.Zero(t, ActiveAsyncGoroutines())
+}
+
+func TestWithWorkerPool_Shedding(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 2))
+
+	// Wedge the only worker so invocations queue up behind it
+	release := make(chan struct{})
+	started := make(chan struct{})
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		close(started)
+		<-release
+	}))
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	<-started
+
+	var mu sync.Mutex
+	var received []string
+	SubscribePriority[progressEvent](-1, HandlerFunc[progressEvent](func(event progressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, "low")
+	}))
+	SubscribePriority[orderEvent](1, HandlerFunc[orderEvent](func(event orderEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, "high")
+	}))
+
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	// The queue is full of low priority invocations, so one is shed, while another
+	// low priority invocation is rejected
+	assert.ErrorIs(t, PublishAsync(progressEvent{Count: 3}), ErrQueueFull)
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 1}))
+	assert.Equal(t, map[int]uint64{-1: 2}, ShedCounts())
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"high", "low"}, received)
+}
+
+type blockObservingCollector struct {
+	recordingCollector
+	blocked []time.Duration
+}
+
+func (c *blockObservingCollector) PublishBlocked(eventType string, blocked time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocked = append(c.blocked, blocked)
+}
+
+func TestWithBlockOnQueueFull(t *testing.T) {
+	reset()
+	collector := &blockObservingCollector{}
+	Configure(WithWorkerPool(1, 1), WithBlockOnQueueFull(), WithMetrics(collector))
+
+	const processing = 20 * time.Millisecond
+	var handled sync.WaitGroup
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		defer handled.Done()
+		time.Sleep(processing)
+	}))
+
+	// With a single worker and room for one queued invocation, later publishes
+	// block until the invocations ahead of them have been handled
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		handled.Add(1)
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 2*processing)
+	handled.Wait()
+	assert.Equal(t, map[int]uint64{}, ShedCounts())
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	assert.Len(t, collector.blocked, 4)
+	assert.Zero(t, collector.blocked[0])
+	var blocked time.Duration
+	for _, d := range collector.blocked {
+		blocked += d
+	}
+	assert.Greater(t, blocked, processing)
+}
+
+func TestShedCounts_NoPool(t *testing.T) {
+	reset()
+	assert.Empty(t, ShedCounts())
+}
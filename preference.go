@@ -0,0 +1,32 @@
+package eventbus
+
+// DispatchMode is the way a handler prefers to be invoked by Publish, declared by
+// implementing DispatchPreferrer.
+type DispatchMode int
+
+const (
+	// DispatchSync invokes the handler on the publishing goroutine.
+	DispatchSync DispatchMode = iota
+	// DispatchAsync invokes the handler asynchronously, the same as PublishAsync.
+	DispatchAsync
+)
+
+// DispatchPreferrer is implemented by handlers that declare how they prefer to be
+// dispatched, keeping the dispatch policy with the handler that knows its own
+// constraints, such as a handler making slow network calls preferring
+// DispatchAsync. Publish and its variants invoke handlers preferring
+// DispatchAsync asynchronously, reporting their errors to the error callback,
+// while invoking the other handlers of the event synchronously as normal.
+// PublishReduce and PublishGather still invoke ResultHandlers preferring
+// DispatchAsync synchronously so their results can be collected. Handlers that
+// don't implement DispatchPreferrer are dispatched synchronously.
+// PublishAsync always invokes every handler asynchronously. The preference is
+// read once when the handler is subscribed.
+type DispatchPreferrer interface {
+	DispatchPreference() DispatchMode
+}
+
+func prefersAsync(handler any) bool {
+	p, ok := handler.(DispatchPreferrer)
+	return ok && p.DispatchPreference() == DispatchAsync
+}
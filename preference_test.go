@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type preferringHandler struct {
+	mode       DispatchMode
+	goroutines chan uint64
+}
+
+func (h preferringHandler) OnEvent(event progressEvent) {
+	h.goroutines <- goroutineID()
+}
+
+func (h preferringHandler) DispatchPreference() DispatchMode {
+	return h.mode
+}
+
+func TestDispatchPreferrer(t *testing.T) {
+	reset()
+	syncGoroutines := make(chan uint64, 2)
+	asyncGoroutines := make(chan uint64, 1)
+	Subscribe[progressEvent](preferringHandler{mode: DispatchSync, goroutines: syncGoroutines})
+	Subscribe[progressEvent](preferringHandler{mode: DispatchAsync, goroutines: asyncGoroutines})
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		syncGoroutines <- goroutineID()
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	publisher := goroutineID()
+	assert.Equal(t, publisher, <-syncGoroutines)
+	assert.Equal(t, publisher, <-syncGoroutines)
+	assert.NotEqual(t, publisher, <-asyncGoroutines)
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+}
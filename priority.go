@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// SubscribePriority registers a handler for a given type with a priority. Handlers
+// with a higher priority are invoked before handlers with a lower priority, and
+// handlers of equal priority are invoked in the order they were registered.
+// Handlers registered with the other Subscribe functions have a priority of
+// zero. When a worker pool is configured with WithWorkerPool, the priority also
+// decides which asynchronous invocations are run first and which are shed when
+// the queue is full. The return value is a subscription ID that can be used to
+// unsubscribe the handler.
+func SubscribePriority[T any](priority int, handler Handler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, handlerInvoker(handler), 2)
+	entries := handlers[eventType]
+	for i := range entries {
+		if entries[i].id == id {
+			entries[i].priority = priority
+			handlers[eventType] = placeByPriority(entries, i)
+			break
+		}
+	}
+	return id
+}
+
+// PublishAsyncPriority behaves like PublishAsync but queues the invocations of
+// the handlers for the worker pool with the given priority rather than the
+// priority of each handler, so critical events, such as alerts, are run before
+// bulk events, such as analytics, queued before them. Invocations of the same
+// priority are run in the order they were queued, and when the queue is full
+// invocations of lower priority are shed first. Without a worker pool configured
+// with WithWorkerPool each invocation runs on its own goroutine and the priority
+// has no effect.
+func PublishAsyncPriority[T any](event T, priority int) error {
+	return publishAsync(event, delivery{ctx: context.Background(), priority: &priority})
+}
+
+// placeByPriority moves the entry at index i so the entries stay ordered by
+// priority, highest first, placing it after the other entries of the same
+// priority. If the entry has to move the entries are copied rather than modified
+// in place, since a dispatch in progress may be iterating over them, and the
+// copy is returned. The caller must hold the write lock.
+func placeByPriority(entries []handlerEntry, i int) []handlerEntry {
+	entry := entries[i]
+	if (i == 0 || entries[i-1].priority >= entry.priority) &&
+		(i == len(entries)-1 || entries[i+1].priority < entry.priority) {
+		return entries
+	}
+	entries = append(entries[:i:i], entries[i+1:]...)
+
+	j := 0
+	for j < len(entries) && entries[j].priority >= entry.priority {
+		j++
+	}
+	return slices.Insert(entries, j, entry)
+}
@@ -0,0 +1,71 @@
+package eventbus
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribePriority(t *testing.T) {
+	reset()
+	var order []string
+	record := func(name string) Handler[progressEvent] {
+		return HandlerFunc[progressEvent](func(progressEvent) {
+			order = append(order, name)
+		})
+	}
+
+	Subscribe[progressEvent](record("default"))
+	SubscribePriority[progressEvent](-1, record("low"))
+	SubscribePriority[progressEvent](10, record("high"))
+	SubscribePriority[progressEvent](10, record("high2"))
+	Subscribe[progressEvent](record("default2"))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.Equal(t, []string{"high", "high2", "default", "default2", "low"}, order)
+}
+
+func TestPublishAsyncPriority(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 16))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, s)
+	}
+	done := make(chan struct{})
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		if event.Count == 0 {
+			close(started)
+			<-release
+			return
+		}
+		record(fmt.Sprintf("analytics %d", event.Count))
+		if event.Count == 3 {
+			close(done)
+		}
+	}))
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(event orderEvent) {
+		record(fmt.Sprintf("alert %d", event.Seq))
+	}))
+
+	// Queue mixed priorities behind the blocked worker
+	assert.NoError(t, PublishAsync(progressEvent{Count: 0}))
+	<-started
+	assert.NoError(t, PublishAsyncPriority(progressEvent{Count: 1}, 0))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	assert.NoError(t, PublishAsyncPriority(orderEvent{Seq: 1}, 10))
+	assert.NoError(t, PublishAsyncPriority(progressEvent{Count: 3}, -1))
+	assert.NoError(t, PublishAsyncPriority(orderEvent{Seq: 2}, 10))
+
+	close(release)
+	<-done
+	assert.Equal(t, []string{"alert 1", "alert 2", "analytics 1", "analytics 2", "analytics 3"}, order)
+}
@@ -0,0 +1,106 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// quarantineBufferSize is the number of events a quarantined handler buffers
+// before further events for it are dropped.
+const quarantineBufferSize = 64
+
+// SubscriptionQuarantined is published when a handler is quarantined because it
+// repeatedly exceeded the threshold set with WithQuarantine.
+type SubscriptionQuarantined struct {
+	// Type is the event type the handler was subscribed to.
+	Type reflect.Type
+	// ID is the subscription ID of the handler.
+	ID uint64
+}
+
+// handlerHealth tracks how long a handler takes to handle events published with
+// Publish and, once the handler is quarantined, delivers events to it on its own
+// goroutine.
+type handlerHealth struct {
+	threshold   time.Duration
+	maxStrikes  int32
+	strikes     atomic.Int32
+	quarantined atomic.Bool
+	once        sync.Once
+	events      chan any
+	done        chan struct{}
+}
+
+func newHandlerHealth(threshold time.Duration, strikes int) *handlerHealth {
+	return &handlerHealth{
+		threshold:  threshold,
+		maxStrikes: int32(strikes),
+		events:     make(chan any, quarantineBufferSize),
+		done:       make(chan struct{}),
+	}
+}
+
+// invoke delivers the event to the handler of the entry. Until the handler is
+// quarantined it is invoked inline and timed, afterwards the event is queued for
+// the handler's own goroutine. The caller must hold the read lock.
+func (h *handlerHealth) invoke(eventType reflect.Type, entry handlerEntry, event any) error {
+	if h.quarantined.Load() {
+		select {
+		case h.events <- event:
+			return nil
+		default:
+			return fmt.Errorf("%w: quarantined subscription %d dropped event %s", ErrBufferFull, entry.id, typeName(eventType))
+		}
+	}
+
+	start := now()
+	var err error
+	unlocked(func() {
+		err = entry.invoke(event)
+	})
+	if now().Sub(start) <= h.threshold {
+		h.strikes.Store(0)
+		return err
+	}
+	if h.strikes.Add(1) >= h.maxStrikes {
+		h.quarantine(eventType, entry)
+	}
+	return err
+}
+
+// quarantine starts the goroutine the handler is invoked on from now on and
+// announces it with a SubscriptionQuarantined event, which is published with the
+// read lock released. On its goroutine the handler is invoked like a handler
+// invoked by PublishAsync, with its panics recovered and its invocations
+// measured. The caller must hold the read lock.
+func (h *handlerHealth) quarantine(eventType reflect.Type, entry handlerEntry) {
+	h.once.Do(func() {
+		callback := cfg.errorCallback
+		invoke := delivery{}.asyncInvoker(eventType, typeName(eventType), entry)
+		go func() {
+			for {
+				select {
+				case event := <-h.events:
+					if err := invoke(event); err != nil && callback != nil {
+						callback(err)
+					}
+				case <-h.done:
+					return
+				}
+			}
+		}()
+		h.quarantined.Store(true)
+
+		unlocked(func() {
+			// Nobody listening for quarantine events isn't an error.
+			_ = Publish(SubscriptionQuarantined{Type: eventType, ID: entry.id})
+		})
+	})
+}
+
+func (h *handlerHealth) stop() {
+	close(h.done)
+}
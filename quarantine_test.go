@@ -0,0 +1,110 @@
+package eventbus
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQuarantine(t *testing.T) {
+	reset()
+	Configure(WithQuarantine(5*time.Millisecond, 2))
+
+	var quarantined []SubscriptionQuarantined
+	Subscribe[SubscriptionQuarantined](HandlerFunc[SubscriptionQuarantined](func(event SubscriptionQuarantined) {
+		quarantined = append(quarantined, event)
+	}))
+
+	var slow, fast atomic.Int32
+	slowID := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		time.Sleep(20 * time.Millisecond)
+		slow.Add(1)
+	}))
+	fastID := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		fast.Add(1)
+	}))
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.Equal(t, int32(2), slow.Load())
+	assert.Len(t, quarantined, 1)
+	assert.Equal(t, slowID, quarantined[0].ID)
+
+	infos := Subscriptions[progressEvent]()
+	assert.True(t, infos[0].Quarantined)
+	assert.Equal(t, fastID, infos[1].ID)
+	assert.False(t, infos[1].Quarantined)
+
+	// The slow handler no longer holds up publishing or the fast handler
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+	assert.Equal(t, int32(5), fast.Load())
+
+	assert.Eventually(t, func() bool {
+		return slow.Load() == 5
+	}, time.Second, 5*time.Millisecond)
+	assert.True(t, Unsubscribe[progressEvent](slowID))
+}
+
+func TestWithQuarantine_SubscribeFromHandler(t *testing.T) {
+	reset()
+	Configure(WithQuarantine(time.Millisecond, 1), WithPanicEvents())
+
+	Subscribe[SubscriptionQuarantined](HandlerFunc[SubscriptionQuarantined](func(SubscriptionQuarantined) {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+	}))
+	panics := make(chan HandlerPanic, 1)
+	Subscribe[HandlerPanic](HandlerFunc[HandlerPanic](func(event HandlerPanic) {
+		panics <- event
+	}))
+	id := Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		if event.Count > 0 {
+			panic("boom")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- Publish(progressEvent{Count: 0})
+	}()
+	select {
+	case err := <-published:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked publishing the SubscriptionQuarantined event")
+	}
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+
+	// Panics of the quarantined handler on its own goroutine are recovered
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	select {
+	case event := <-panics:
+		assert.Equal(t, id, event.SubscriptionID)
+	case <-time.After(time.Second):
+		t.Fatal("panic of quarantined handler was not recovered")
+	}
+	assert.True(t, Unsubscribe[progressEvent](id))
+}
+
+func TestWithQuarantine_ResetsStrikes(t *testing.T) {
+	reset()
+	Configure(WithQuarantine(5*time.Millisecond, 2))
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		if event.Count%2 == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.False(t, Subscriptions[progressEvent]()[0].Quarantined)
+}
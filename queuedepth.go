@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// queueDepth is the number of asynchronous handler invocations submitted that
+	// haven't started running yet.
+	queueDepth atomic.Int64
+
+	// typeDepths holds the number of asynchronous handler invocations submitted
+	// that haven't started running yet for each event type.
+	typeDepths = sync.Map{}
+)
+
+func typeDepth(eventType reflect.Type) *atomic.Int64 {
+	if depth, ok := typeDepths.Load(eventType); ok {
+		return depth.(*atomic.Int64)
+	}
+	depth, _ := typeDepths.LoadOrStore(eventType, &atomic.Int64{})
+	return depth.(*atomic.Int64)
+}
+
+// enqueued counts an asynchronous handler invocation for an event of the given
+// type as pending until dequeued is called for it, once it starts running or is
+// shed.
+func enqueued(eventType reflect.Type) {
+	typeDepth(eventType).Add(1)
+	queueDepth.Add(1)
+}
+
+func dequeued(eventType reflect.Type) {
+	typeDepth(eventType).Add(-1)
+	queueDepth.Add(-1)
+}
+
+// QueueDepth returns the number of asynchronous handler invocations that are
+// pending, having been submitted by PublishAsync but not yet started, including
+// invocations queued for the worker pool and spilled to its spill store. With
+// WithConsistentOrdering the handlers of an event are invoked by a single task,
+// which is counted once. QueueDepth doesn't take any locks so it can be sampled
+// frequently, for example to autoscale consumers, without slowing dispatch down.
+func QueueDepth() int {
+	return int(queueDepth.Load())
+}
+
+// QueueDepthByType returns the number of asynchronous handler invocations for
+// events of the given type that are pending, see QueueDepth.
+func QueueDepthByType(eventType reflect.Type) int {
+	depth, ok := typeDepths.Load(eventType)
+	if !ok {
+		return 0
+	}
+	return int(depth.(*atomic.Int64).Load())
+}
@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueDepth(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 16))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(event orderEvent) {
+		if event.Seq == 0 {
+			close(started)
+			<-release
+		}
+	}))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	assert.Equal(t, 0, QueueDepth())
+
+	// Fill the queue behind the blocked handler
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 0}))
+	<-started
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, PublishAsync(orderEvent{Seq: i}))
+	}
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+
+	assert.Equal(t, 7, QueueDepth())
+	assert.Equal(t, 5, QueueDepthByType(reflect.TypeOf(orderEvent{})))
+	assert.Equal(t, 2, QueueDepthByType(reflect.TypeOf(progressEvent{})))
+	assert.Equal(t, 0, QueueDepthByType(reflect.TypeOf(userCreatedEvent{})))
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return QueueDepth() == 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, QueueDepthByType(reflect.TypeOf(orderEvent{})))
+}
+
+func TestQueueDepth_Shed(t *testing.T) {
+	reset()
+	Configure(WithWorkerPool(1, 2))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(event orderEvent) {
+		if event.Seq == 0 {
+			close(started)
+			<-release
+		}
+	}))
+
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 0}))
+	<-started
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 1}))
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 2}))
+	assert.ErrorIs(t, PublishAsync(orderEvent{Seq: 3}), ErrQueueFull)
+	assert.Equal(t, 2, QueueDepth())
+}
@@ -0,0 +1,112 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when publishing an event exceeds the rate limit
+// set for its type with SetRateLimit.
+var ErrRateLimited = errors.New("eventbus: publish rate limit exceeded")
+
+// rateLimiters holds the token bucket for each rate limited event type. It is a
+// sync.Map so publishing can check the limit without holding the lock while
+// waiting for a token.
+var rateLimiters = sync.Map{}
+
+// tokenBucket is a token bucket holding up to capacity tokens which refills at a
+// rate of rate tokens per second.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(perSecond int) *tokenBucket {
+	return &tokenBucket{
+		rate:     float64(perSecond),
+		capacity: float64(perSecond),
+		tokens:   float64(perSecond),
+		last:     now(),
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+}
+
+// allow takes a token if one is available, returning false if there are none.
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// reserve takes a token and returns how long the caller must wait before the
+// token is available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now())
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// SetRateLimit limits the number of events of the given type that can be
+// published per second, across all publishers, using a token bucket that allows
+// bursts of up to perSecond events. By default publishes exceeding the rate are
+// rejected with ErrRateLimited, with WithBlockOnRateLimit publishes instead
+// block until the rate allows the event to be published. A perSecond of zero or
+// less removes the rate limit for the type.
+func SetRateLimit[T any](perSecond int) {
+	eventType := reflect.TypeOf(*new(T))
+	if perSecond <= 0 {
+		rateLimiters.Delete(eventType)
+		return
+	}
+	rateLimiters.Store(eventType, newTokenBucket(perSecond))
+}
+
+// waitRateLimit enforces the rate limit for the event type if one is set. It must
+// be called without holding the lock since it may block.
+func waitRateLimit(eventType reflect.Type) error {
+	limiter, ok := rateLimiters.Load(eventType)
+	if !ok {
+		return nil
+	}
+
+	mu.RLock()
+	block, name := cfg.blockOnRateLimit, typeName(eventType)
+	mu.RUnlock()
+
+	bucket := limiter.(*tokenBucket)
+	if block {
+		if wait := bucket.reserve(); wait > 0 {
+			<-currentClock().After(wait)
+		}
+		return nil
+	}
+	if !bucket.allow() {
+		return fmt.Errorf("%w for event %s", ErrRateLimited, name)
+	}
+	return nil
+}
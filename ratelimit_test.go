@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	reset()
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {}))
+	SetRateLimit[progressEvent](10)
+
+	published, limited := 0, 0
+	for i := 0; i < 20; i++ {
+		err := Publish(progressEvent{Count: i})
+		if err != nil {
+			assert.ErrorIs(t, err, ErrRateLimited)
+			limited++
+			continue
+		}
+		published++
+	}
+	assert.Equal(t, 10, published)
+	assert.Equal(t, 10, limited)
+	assert.ErrorIs(t, PublishAsync(progressEvent{Count: 1}), ErrRateLimited)
+
+	// Tokens are replenished at the configured rate
+	time.Sleep(250 * time.Millisecond)
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+
+	// Other types are not affected
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {}))
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+
+	SetRateLimit[progressEvent](0)
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+}
+
+func TestSetRateLimit_Block(t *testing.T) {
+	reset()
+	Configure(WithBlockOnRateLimit())
+	received := 0
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received++
+	}))
+	SetRateLimit[progressEvent](20)
+
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.Equal(t, 30, received)
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+}
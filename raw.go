@@ -0,0 +1,94 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RawEvent is an event along with its concrete type, as received by handlers
+// subscribed with SubscribeRaw.
+type RawEvent struct {
+	// Type is the type of the event.
+	Type reflect.Type
+	// Value is the event.
+	Value any
+}
+
+type rawEntry struct {
+	id      uint64
+	handler func(event RawEvent)
+}
+
+// rawHandlers are the handlers subscribed with SubscribeRaw, in the order they
+// were registered.
+var rawHandlers = make([]rawEntry, 0)
+
+// SubscribeRaw registers a handler that is invoked for every event published,
+// regardless of its type and whether other handlers are registered for it, such
+// as a generic audit or logging subscriber. Unlike a fallback handler it receives
+// the event wrapped in a RawEvent carrying its type. Raw handlers are invoked
+// before the handlers registered for the event type, synchronously for events
+// published with Publish and asynchronously for events published with
+// PublishAsync. Raw handlers don't count as handlers of the event, so publishing
+// an event without other handlers still returns an error. The return value is a
+// subscription ID that can be used to unsubscribe the handler with
+// UnsubscribeRaw.
+func SubscribeRaw(handler func(event RawEvent)) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := generateHandlerId()
+	rawHandlers = append(rawHandlers, rawEntry{id: id, handler: handler})
+	recordSubscribe()
+	return id
+}
+
+// UnsubscribeRaw removes a raw handler with the given subscription ID. If the raw
+// handler is not found, it returns false.
+func UnsubscribeRaw(subscriptionID uint64) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, r := range rawHandlers {
+		if r.id == subscriptionID {
+			rawHandlers = append(rawHandlers[:i:i], rawHandlers[i+1:]...)
+			recordUnsubscribe()
+			return true
+		}
+	}
+	return false
+}
+
+// dispatchRaw invokes the raw handlers with the event. The caller must hold the
+// read lock.
+func dispatchRaw(eventType reflect.Type, event any) {
+	for _, r := range rawHandlers {
+		unlocked(func() {
+			r.handler(RawEvent{Type: eventType, Value: event})
+		})
+	}
+}
+
+// dispatchRawAsync invokes the raw handlers with the event asynchronously. Raw
+// handlers that can't be run are reported to the error callback rather than
+// failing the publish. The caller must hold the read lock.
+func dispatchRawAsync[T any](eventType reflect.Type, event T, d delivery) {
+	callback, name := cfg.errorCallback, typeName(eventType)
+	for _, r := range rawHandlers {
+		fn, raw := r.handler, RawEvent{Type: eventType, Value: copyAsync(event)}
+		err := d.submitAsync(eventType, 0, func() {
+			if d.expired(name, callback) {
+				return
+			}
+			fn(raw)
+		}, nil)
+		if err != nil && callback != nil {
+			err = fmt.Errorf("%w: raw subscription %d", err, r.id)
+			unlocked(func() {
+				callback(err)
+			})
+		}
+	}
+}
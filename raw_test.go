@@ -0,0 +1,74 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeRaw(t *testing.T) {
+	reset()
+	var received []RawEvent
+	id := SubscribeRaw(func(event RawEvent) {
+		received = append(received, event)
+	})
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {}))
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	// Raw handlers don't count as handlers of the event
+	assert.Error(t, Publish(progressEvent{Count: 1}))
+	assert.Equal(t, []RawEvent{
+		{Type: reflect.TypeOf(userCreatedEvent{}), Value: userCreatedEvent{Name: "John Doe"}},
+		{Type: reflect.TypeOf(progressEvent{}), Value: progressEvent{Count: 1}},
+	}, received)
+
+	assert.True(t, UnsubscribeRaw(id))
+	assert.False(t, UnsubscribeRaw(id))
+	assert.NoError(t, Publish(userCreatedEvent{Name: "Jane Doe"}))
+	assert.Len(t, received, 2)
+	assert.Equal(t, SubscriptionStats{Subscribes: 2, Unsubscribes: 1, Active: 1}, Stats())
+}
+
+func TestSubscribeRaw_PublishAsync(t *testing.T) {
+	reset()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var received []RawEvent
+	SubscribeRaw(func(event RawEvent) {
+		defer wg.Done()
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event)
+	})
+	SubscribeFallback(func(any) {})
+
+	wg.Add(1)
+	assert.NoError(t, PublishAsync(orderEvent{Seq: 1}))
+	wg.Wait()
+	assert.Equal(t, []RawEvent{{Type: reflect.TypeOf(orderEvent{}), Value: orderEvent{Seq: 1}}}, received)
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestSubscribeRaw_SubscribeFromHandler(t *testing.T) {
+	reset()
+	var received []orderEvent
+	Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+	SubscribeRaw(func(event RawEvent) {
+		if event.Value == (orderEvent{Seq: 1}) {
+			Subscribe[orderEvent](HandlerFunc[orderEvent](func(event orderEvent) {
+				received = append(received, event)
+			}))
+		}
+	})
+
+	// The handler subscribed while the event is dispatched doesn't receive it
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+	assert.Empty(t, received)
+	assert.NoError(t, Publish(orderEvent{Seq: 2}))
+	assert.Equal(t, []orderEvent{{Seq: 2}}, received)
+}
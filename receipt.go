@@ -0,0 +1,72 @@
+package eventbus
+
+// DeliveryReceipt records the outcome of each handler invoked when publishing an
+// event with PublishWithReceipt.
+type DeliveryReceipt struct {
+	// Acked contains the subscription IDs of the handlers that successfully
+	// handled the event, in the order they were invoked.
+	Acked []uint64
+	// Failed contains the handlers that returned an error handling the event, in
+	// the order they were invoked.
+	Failed []DeliveryFailure
+
+	// invoked contains the subscription IDs of every handler invoked, in the
+	// order they were invoked.
+	invoked []uint64
+}
+
+// DeliveryFailure describes a handler that failed to handle an event.
+type DeliveryFailure struct {
+	SubscriptionID uint64
+	Err            error
+}
+
+func (r *DeliveryReceipt) ack(id uint64) {
+	if r == nil {
+		return
+	}
+	r.Acked = append(r.Acked, id)
+	r.invoked = append(r.invoked, id)
+}
+
+func (r *DeliveryReceipt) fail(id uint64, err error) {
+	if r == nil {
+		return
+	}
+	r.Failed = append(r.Failed, DeliveryFailure{SubscriptionID: id, Err: err})
+	r.invoked = append(r.invoked, id)
+}
+
+// PublishWithReceipt behaves like Publish but also returns a DeliveryReceipt
+// describing which handlers acknowledged the event and which failed. A Handler
+// acknowledges an event by returning, while an ErrorHandler acknowledges an
+// event by returning a nil error. The returned error is the same error Publish
+// would have returned. When the event is buffered because its type is paused the
+// receipt is empty, and the handlers invoked by Resume aren't recorded in it.
+func PublishWithReceipt[T any](event T) (DeliveryReceipt, error) {
+	receipt := DeliveryReceipt{}
+	err := publish(event, delivery{receipt: &receipt})
+	return receipt, err
+}
+
+// PublishN behaves like Publish but also returns the number of handlers that were
+// invoked, including handlers that returned an error. When the event is handled
+// by fallback handlers the number of fallback handlers invoked is returned, and
+// when the event is buffered because its type is paused zero is returned.
+func PublishN[T any](event T) (int, error) {
+	receipt := DeliveryReceipt{}
+	err := publish(event, delivery{receipt: &receipt})
+	return len(receipt.Acked) + len(receipt.Failed), err
+}
+
+// PublishTrace behaves like Publish but also returns the subscription IDs of the
+// handlers that were invoked, in the order they were invoked, including handlers
+// that returned an error. Handlers skipped because they are suspended, disabled
+// by their gate or not selected aren't included. When the event is handled by
+// fallback handlers their IDs are returned, and when the event is dropped by a
+// filter or buffered because its type is paused no IDs are returned.
+func PublishTrace[T any](event T) ([]uint64, error) {
+	receipt := DeliveryReceipt{}
+	err := publish(event, delivery{receipt: &receipt})
+	return receipt.invoked, err
+}
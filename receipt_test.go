@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishWithReceipt(t *testing.T) {
+	reset()
+	errFailed := errors.New("failed to handle event")
+	id1 := Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {}))
+	id2 := SubscribeErrorHandler[userCreatedEvent](ErrorHandlerFunc[userCreatedEvent](func(event userCreatedEvent) error {
+		return errFailed
+	}))
+	id3 := SubscribeErrorHandler[userCreatedEvent](ErrorHandlerFunc[userCreatedEvent](func(event userCreatedEvent) error {
+		return nil
+	}))
+
+	receipt, err := PublishWithReceipt(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.ErrorIs(t, err, errFailed)
+	assert.Equal(t, []uint64{id1, id3}, receipt.Acked)
+	assert.Equal(t, []DeliveryFailure{{SubscriptionID: id2, Err: errFailed}}, receipt.Failed)
+}
+
+func TestPublishWithReceipt_NoHandler(t *testing.T) {
+	reset()
+	receipt, err := PublishWithReceipt(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.Error(t, err)
+	assert.Empty(t, receipt.Acked)
+	assert.Empty(t, receipt.Failed)
+}
+
+func TestPublishN(t *testing.T) {
+	reset()
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {}))
+	SubscribeErrorHandler[userCreatedEvent](ErrorHandlerFunc[userCreatedEvent](func(event userCreatedEvent) error {
+		return errors.New("failed to handle event")
+	}))
+	SubscribeFallback(func(event any) {})
+
+	n, err := PublishN(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.Error(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = PublishN(progressEvent{Count: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	Pause[userCreatedEvent]()
+	n, err = PublishN(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestPublishTrace(t *testing.T) {
+	reset()
+
+	low := SubscribePriority[progressEvent](-1, HandlerFunc[progressEvent](func(progressEvent) {}))
+	failing := SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(progressEvent) error {
+		return errors.New("boom")
+	}))
+	SubscribeGated[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}), func() bool {
+		return false
+	})
+	suspended := Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	Suspend[progressEvent](suspended)
+	high := SubscribePriority[progressEvent](1, HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	ids, err := PublishTrace(progressEvent{Count: 1})
+	assert.Error(t, err)
+	assert.Equal(t, []uint64{high, failing, low}, ids)
+
+	// Events dropped by a filter invoke no handlers
+	AddFilter[progressEvent](func(event progressEvent) (progressEvent, bool) {
+		return event, event.Count > 1
+	})
+	ids, err = PublishTrace(progressEvent{Count: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrReentrantInvocation is reported to the error callback when a handler
+// implementing NonReentrant is invoked while a previous invocation of it is still
+// running.
+var ErrReentrantInvocation = errors.New("eventbus: non-reentrant handler invoked concurrently")
+
+// NonReentrant is a marker interface for handlers that aren't safe to invoke
+// concurrently. It is a diagnostic aid for finding concurrency bugs: when a
+// handler implementing NonReentrant is invoked while a previous invocation of the
+// same subscription is still running, such as by PublishAsync delivering two
+// events at once, an error wrapping ErrReentrantInvocation is reported to the
+// callback set with WithErrorCallback. The overlapping invocation still runs, the
+// bus doesn't serialize invocations of the handler.
+type NonReentrant interface {
+	NonReentrant()
+}
+
+// reentrancyGuard counts the running invocations of a NonReentrant handler.
+type reentrancyGuard struct {
+	active atomic.Int32
+}
+
+// newReentrancyGuard returns a guard for the handler if it implements
+// NonReentrant, otherwise nil.
+func newReentrancyGuard(handler any) *reentrancyGuard {
+	if _, ok := handler.(NonReentrant); ok {
+		return &reentrancyGuard{}
+	}
+	return nil
+}
+
+// enter records the start of an invocation of the handler with the subscription
+// ID, reporting a violation to callback if another invocation is running.
+func (g *reentrancyGuard) enter(name string, id uint64, callback func(err error)) {
+	if g.active.Add(1) > 1 && callback != nil {
+		callback(fmt.Errorf("%w: subscription %d for event %s", ErrReentrantInvocation, id, name))
+	}
+}
+
+func (g *reentrancyGuard) exit() {
+	g.active.Add(-1)
+}
+
+// guarding wraps invoke so each invocation is recorded by the guard.
+func (g *reentrancyGuard) guarding(name string, id uint64, callback func(err error), invoke func(event any) error) func(event any) error {
+	return func(event any) error {
+		g.enter(name, id, callback)
+		defer g.exit()
+		return invoke(event)
+	}
+}
@@ -0,0 +1,99 @@
+package eventbus
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nonReentrantHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *nonReentrantHandler) NonReentrant() {}
+
+func (h *nonReentrantHandler) OnEvent(event progressEvent) {
+	h.started <- struct{}{}
+	<-h.release
+}
+
+func TestNonReentrant(t *testing.T) {
+	reset()
+	var mu sync.Mutex
+	var errs []error
+	Configure(WithErrorCallback(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+
+	h := &nonReentrantHandler{started: make(chan struct{}, 2), release: make(chan struct{})}
+	id := Subscribe[progressEvent](h)
+
+	// Both invocations are running before either is released
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	<-h.started
+	<-h.started
+	close(h.release)
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], ErrReentrantInvocation)
+		assert.Contains(t, errs[0].Error(), "subscription "+strconv.FormatUint(id, 10))
+	}
+}
+
+func TestNonReentrant_Sequential(t *testing.T) {
+	reset()
+	var errs []error
+	Configure(WithErrorCallback(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	h := &nonReentrantHandler{started: make(chan struct{}, 2), release: make(chan struct{})}
+	close(h.release)
+	Subscribe[progressEvent](h)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.Empty(t, errs)
+}
+
+func TestNonReentrant_SubscribeFromCallback(t *testing.T) {
+	reset()
+	reported := make(chan struct{})
+	Configure(WithErrorCallback(func(err error) {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+		close(reported)
+	}))
+
+	h := &nonReentrantHandler{started: make(chan struct{}, 2), release: make(chan struct{})}
+	Subscribe[progressEvent](h)
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	<-h.started
+
+	// The violation of the synchronous invocation is reported without the lock
+	published := make(chan error, 1)
+	go func() {
+		published <- Publish(progressEvent{Count: 2})
+	}()
+	select {
+	case <-reported:
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked subscribing from the error callback")
+	}
+	close(h.release)
+	assert.NoError(t, <-published)
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,89 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTooManyHandlers is reported when subscribing a handler would exceed the
+// number of handlers allowed per event type by WithMaxHandlers.
+var ErrTooManyHandlers = errors.New("eventbus: too many handlers for event type")
+
+// Registration is a handler bound to the event type it handles, created with On
+// and added to a Registrar.
+type Registration struct {
+	eventType reflect.Type
+	handler   any
+	invoke    func(event any) error
+	name      string
+}
+
+// On creates a Registration of a handler for events of type T to be added to a
+// Registrar. On panics with ErrNilHandler if the handler is nil.
+func On[T any](handler Handler[T]) Registration {
+	mustNotBeNil(handler)
+	return Registration{
+		eventType: reflect.TypeOf(*new(T)),
+		handler:   handler,
+		invoke:    handlerInvoker(handler),
+	}
+}
+
+// Registrar queues registrations which Register subscribes together.
+type Registrar struct {
+	registrations []Registration
+}
+
+// Add queues a registration to be subscribed when the function passed to
+// Register returns.
+func (r *Registrar) Add(registration Registration) {
+	r.registrations = append(r.registrations, registration)
+}
+
+// Register subscribes every handler added to the Registrar by fn as a single
+// transaction. The handlers are subscribed under one acquisition of the lock, so
+// publishers observe either none or all of them, and if any of them can't be
+// subscribed, such as because it would exceed the limit set with
+// WithMaxHandlers or its schema version is rejected by the registry set with
+// WithSchemaRegistry, none of them are subscribed and an error is returned. On
+// success the subscription IDs of the handlers are returned in the order they
+// were added.
+//
+//	ids, err := eventbus.Register(func(r *eventbus.Registrar) {
+//		r.Add(eventbus.On[UserCreated](userCreatedHandler))
+//		r.Add(eventbus.On[UserDeleted](userDeletedHandler))
+//	})
+func Register(fn func(r *Registrar)) ([]uint64, error) {
+	r := &Registrar{}
+	fn(r)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	if cfg.maxHandlers > 0 {
+		counts := make(map[reflect.Type]int)
+		for _, reg := range r.registrations {
+			counts[reg.eventType]++
+			if len(handlers[reg.eventType])+counts[reg.eventType] > cfg.maxHandlers {
+				return nil, fmt.Errorf("%w %s", ErrTooManyHandlers, typeName(reg.eventType))
+			}
+		}
+	}
+
+	for _, reg := range r.registrations {
+		if err := checkSchema(reg.eventType, reg.handler); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]uint64, 0, len(r.registrations))
+	for _, reg := range r.registrations {
+		id := subscribe(reg.eventType, reg.handler, reg.invoke, 2)
+		if reg.name != "" {
+			entryByID(reg.eventType, id).name = reg.name
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
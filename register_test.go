@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	reset()
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	h := new(userCreatedHandler)
+	h.On("OnEvent", event).Return()
+	received := 0
+
+	ids, err := Register(func(r *Registrar) {
+		r.Add(On[userCreatedEvent](h))
+		r.Add(On[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+			received++
+		})))
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+	assert.Equal(t, ids[0], Subscriptions[userCreatedEvent]()[0].ID)
+	assert.Equal(t, ids[1], Subscriptions[progressEvent]()[0].ID)
+
+	assert.NoError(t, Publish(event))
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+	assert.Equal(t, 1, received)
+}
+
+func TestRegister_Rollback(t *testing.T) {
+	reset()
+	Configure(WithMaxHandlers(2))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	ids, err := Register(func(r *Registrar) {
+		r.Add(On[userCreatedEvent](new(userCreatedHandler)))
+		r.Add(On[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {})))
+		r.Add(On[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {})))
+	})
+	assert.ErrorIs(t, err, ErrTooManyHandlers)
+	assert.Nil(t, ids)
+	assert.Empty(t, Subscriptions[userCreatedEvent]())
+	assert.Len(t, Subscriptions[progressEvent](), 1)
+	assert.Equal(t, uint64(1), Stats().Active)
+}
+
+func TestWithMaxHandlers(t *testing.T) {
+	reset()
+	Configure(WithMaxHandlers(1))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	assert.Panics(t, func() {
+		Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	})
+	assert.Len(t, Subscriptions[progressEvent](), 1)
+
+	// The limit applies to each event type separately
+	assert.NotPanics(t, func() {
+		Subscribe[userCreatedEvent](new(userCreatedHandler))
+	})
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// Reorder rearranges the handlers registered for the specified type so they are
+// invoked in the order of the given subscription IDs, allowing dispatch order to
+// be changed at runtime without resubscribing, such as moving a logging handler
+// last. Handlers whose IDs aren't listed are placed after the listed handlers in
+// their current relative order, and IDs of unknown subscriptions are ignored.
+// Priority still takes precedence over the given order, so handlers are only
+// reordered among handlers of the same priority, see SubscribePriority.
+func Reorder[T any](ids []uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	rank := make(map[uint64]int, len(ids))
+	for i, id := range ids {
+		if _, ok := rank[id]; !ok {
+			rank[id] = i
+		}
+	}
+	rankOf := func(entry handlerEntry) int {
+		if r, ok := rank[entry.id]; ok {
+			return r
+		}
+		return len(ids)
+	}
+
+	// The handlers are sorted in a copy since a dispatch in progress may be
+	// iterating over them.
+	eventType := reflect.TypeOf(*new(T))
+	entries := slices.Clone(handlers[eventType])
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].priority != entries[j].priority {
+			return entries[i].priority > entries[j].priority
+		}
+		return rankOf(entries[i]) < rankOf(entries[j])
+	})
+	handlers[eventType] = entries
+}
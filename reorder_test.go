@@ -0,0 +1,48 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorder(t *testing.T) {
+	reset()
+	var order []string
+	subscribeNamed := func(name string) uint64 {
+		return Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+			order = append(order, name)
+		}))
+	}
+	logging := subscribeNamed("logging")
+	audit := subscribeNamed("audit")
+	email := subscribeNamed("email")
+
+	Reorder[userCreatedEvent]([]uint64{audit, 999})
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.Equal(t, []string{"audit", "logging", "email"}, order)
+
+	order = nil
+	Reorder[userCreatedEvent]([]uint64{email, audit, logging})
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.Equal(t, []string{"email", "audit", "logging"}, order)
+}
+
+func TestReorder_Priority(t *testing.T) {
+	reset()
+	var order []int
+	first := SubscribePriority[userCreatedEvent](1, HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		order = append(order, 1)
+	}))
+	second := Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		order = append(order, 2)
+	}))
+	third := Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		order = append(order, 3)
+	}))
+
+	// The handler with a higher priority stays first
+	Reorder[userCreatedEvent]([]uint64{third, second, first})
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.Equal(t, []int{1, 3, 2}, order)
+}
@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// ReplaceHandler atomically replaces the handler of the subscription with the
+// given ID for the specified type, returning false if there is no such
+// subscription. The subscription keeps its ID and the name, key and priority it
+// was subscribed with, and there is no moment where neither handler is
+// registered, so no event is missed while hot reloading handler logic. Events
+// published after ReplaceHandler returns are delivered to the new handler.
+// Resources owned by the old handler, such as the goroutines of a partitioned
+// handler, are released. ReplaceHandler panics with ErrNilHandler if the handler
+// is nil.
+func ReplaceHandler[T any](subscriptionID uint64, handler Handler[T]) bool {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	entry := entryByID(reflect.TypeOf(*new(T)), subscriptionID)
+	if entry == nil {
+		return false
+	}
+
+	if s, ok := entry.handler.(stopper); ok {
+		s.stop()
+	}
+	entry.handler = handler
+	entry.invoke = handlerInvoker(handler)
+	entry.guard = newReentrancyGuard(handler)
+	entry.prefersAsync = prefersAsync(handler)
+	entry.invokeCtx = nil
+	entry.result = nil
+	if entry.health != nil {
+		entry.health.stop()
+		entry.health = newHandlerHealth(entry.health.threshold, int(entry.health.maxStrikes))
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceHandler(t *testing.T) {
+	reset()
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	old := new(userCreatedHandler)
+	old.On("OnEvent", event).Return()
+	id := SubscribeNamed[userCreatedEvent]("users", old)
+
+	assert.NoError(t, Publish(event))
+
+	replacement := new(userCreatedHandler)
+	replacement.On("OnEvent", event).Return()
+	assert.True(t, ReplaceHandler[userCreatedEvent](id, replacement))
+
+	assert.NoError(t, Publish(event))
+	old.AssertNumberOfCalls(t, "OnEvent", 1)
+	replacement.AssertNumberOfCalls(t, "OnEvent", 1)
+
+	subs := Subscriptions[userCreatedEvent]()
+	assert.Len(t, subs, 1)
+	assert.Equal(t, id, subs[0].ID)
+	assert.Equal(t, "users", subs[0].Name)
+
+	assert.True(t, Unsubscribe[userCreatedEvent](id))
+	assert.Equal(t, uint64(0), Stats().Active)
+}
+
+func TestReplaceHandler_NotFound(t *testing.T) {
+	reset()
+	id := Subscribe[userCreatedEvent](new(userCreatedHandler))
+	assert.False(t, ReplaceHandler[userCreatedEvent](id+1, new(userCreatedHandler)))
+	assert.False(t, ReplaceHandler[progressEvent](id, HandlerFunc[progressEvent](func(progressEvent) {})))
+}
+
+func TestReplaceHandler_ErrorHandler(t *testing.T) {
+	reset()
+	id := SubscribeErrorHandler[progressEvent](ErrorHandlerFunc[progressEvent](func(progressEvent) error {
+		return assert.AnError
+	}))
+	assert.Error(t, Publish(progressEvent{Count: 1}))
+
+	assert.True(t, ReplaceHandler[progressEvent](id, HandlerFunc[progressEvent](func(progressEvent) {})))
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+}
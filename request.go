@@ -0,0 +1,109 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoResponder is returned by Request and RequestCtx when no responder is
+// registered for the request and response types.
+var ErrNoResponder = errors.New("eventbus: no responder registered")
+
+type responderKey struct {
+	request  reflect.Type
+	response reflect.Type
+}
+
+type responderEntry struct {
+	id      uint64
+	respond func(request any) (any, error)
+}
+
+var responders = make(map[responderKey]responderEntry)
+
+// Respond registers a responder that answers requests of type Req with a
+// response of type Resp. Only a single responder can be registered for a pair of
+// request and response types, registering another responder replaces the
+// existing one. The return value is a subscription ID that can be used to remove
+// the responder with Unrespond.
+func Respond[Req, Resp any](fn func(request Req) (Resp, error)) uint64 {
+	mustNotBeNil(fn)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := generateHandlerId()
+	responders[responderKeyOf[Req, Resp]()] = responderEntry{
+		id: id,
+		respond: func(request any) (any, error) {
+			return fn(request.(Req))
+		},
+	}
+	return id
+}
+
+// Unrespond removes the responder with the given subscription ID for the request
+// and response types. If the responder is not found, it returns false.
+func Unrespond[Req, Resp any](subscriptionID uint64) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := responderKeyOf[Req, Resp]()
+	if r, ok := responders[key]; ok && r.id == subscriptionID {
+		delete(responders, key)
+		return true
+	}
+	return false
+}
+
+// Request sends a request to the responder registered for the request and
+// response types and returns its response. If no responder is registered
+// ErrNoResponder is returned. Request blocks until the responder returns, use
+// RequestCtx to bound how long to wait for the response.
+func Request[Req, Resp any](request Req) (Resp, error) {
+	return RequestCtx[Req, Resp](context.Background(), request)
+}
+
+// RequestCtx behaves like Request but stops waiting for the response when the
+// context is done, returning the context error, such as context.DeadlineExceeded
+// when the deadline of the context is exceeded. The responder is invoked in its
+// own goroutine and is not interrupted when the context is done, its response is
+// discarded.
+func RequestCtx[Req, Resp any](ctx context.Context, request Req) (Resp, error) {
+	mu.RLock()
+	r, ok := responders[responderKeyOf[Req, Resp]()]
+	mu.RUnlock()
+
+	if !ok {
+		return *new(Resp), fmt.Errorf("%w for request %T", ErrNoResponder, request)
+	}
+
+	type result struct {
+		response any
+		err      error
+	}
+	results := make(chan result, 1)
+	go func() {
+		response, err := r.respond(request)
+		results <- result{response: response, err: err}
+	}()
+
+	select {
+	case res := <-results:
+		if res.err != nil {
+			return *new(Resp), res.err
+		}
+		return res.response.(Resp), nil
+	case <-ctx.Done():
+		return *new(Resp), ctx.Err()
+	}
+}
+
+func responderKeyOf[Req, Resp any]() responderKey {
+	return responderKey{
+		request:  reflect.TypeOf((*Req)(nil)).Elem(),
+		response: reflect.TypeOf((*Resp)(nil)).Elem(),
+	}
+}
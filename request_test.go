@@ -0,0 +1,72 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lookupUserRequest struct {
+	ID int
+}
+
+func TestRequest(t *testing.T) {
+	reset()
+	Respond[lookupUserRequest, string](func(request lookupUserRequest) (string, error) {
+		return "user-" + strconv.Itoa(request.ID), nil
+	})
+
+	resp, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-42", resp)
+}
+
+func TestRequest_NoResponder(t *testing.T) {
+	reset()
+	_, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.ErrorIs(t, err, ErrNoResponder)
+
+	id := Respond[lookupUserRequest, string](func(request lookupUserRequest) (string, error) {
+		return "", nil
+	})
+	assert.True(t, Unrespond[lookupUserRequest, string](id))
+	assert.False(t, Unrespond[lookupUserRequest, string](id))
+	_, err = Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.ErrorIs(t, err, ErrNoResponder)
+}
+
+func TestRequest_ResponderError(t *testing.T) {
+	reset()
+	errNotFound := errors.New("user not found")
+	Respond[lookupUserRequest, string](func(request lookupUserRequest) (string, error) {
+		return "", errNotFound
+	})
+
+	_, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.ErrorIs(t, err, errNotFound)
+}
+
+func TestRequestCtx(t *testing.T) {
+	reset()
+	Respond[lookupUserRequest, string](func(request lookupUserRequest) (string, error) {
+		if request.ID == 0 {
+			time.Sleep(500 * time.Millisecond)
+		}
+		return "user-" + strconv.Itoa(request.ID), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := RequestCtx[lookupUserRequest, string](ctx, lookupUserRequest{ID: 0})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	resp, err := RequestCtx[lookupUserRequest, string](ctx, lookupUserRequest{ID: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-42", resp)
+}
@@ -0,0 +1,78 @@
+package eventbus
+
+import (
+	"sync"
+	"time"
+)
+
+// responseCache caches the responses of a responder registered with
+// RespondCached, keyed by request.
+type responseCache[Req comparable, Resp any] struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	responses map[Req]cachedResponse[Resp]
+	nextPrune time.Time
+}
+
+type cachedResponse[Resp any] struct {
+	response  Resp
+	expiresAt time.Time
+}
+
+// get returns the cached response to the request if it hasn't expired.
+func (c *responseCache[Req, Resp]) get(request Req) (Resp, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.responses[request]
+	if !ok || now().After(cached.expiresAt) {
+		return *new(Resp), false
+	}
+	return cached.response, true
+}
+
+// put caches the response to the request for the TTL, forgetting expired
+// responses at most once per TTL.
+func (c *responseCache[Req, Resp]) put(request Req, response Resp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := now()
+	if current.After(c.nextPrune) {
+		for r, cached := range c.responses {
+			if current.After(cached.expiresAt) {
+				delete(c.responses, r)
+			}
+		}
+		c.nextPrune = current.Add(c.ttl)
+	}
+	c.responses[request] = cachedResponse[Resp]{response: response, expiresAt: current.Add(c.ttl)}
+}
+
+// RespondCached behaves like Respond but caches the responses of the responder
+// for ttl, so repeated identical requests within the TTL are answered from the
+// cache without invoking the responder. This avoids redundant downstream calls
+// for expensive responders whose response only depends on the request. Requests
+// are identical when they are equal, so the request type must be comparable.
+// Errors are not cached. Identical requests made concurrently before the first
+// response is cached may each invoke the responder.
+func RespondCached[Req comparable, Resp any](fn func(request Req) (Resp, error), ttl time.Duration) uint64 {
+	mustNotBeNil(fn)
+
+	cache := &responseCache[Req, Resp]{
+		ttl:       ttl,
+		responses: make(map[Req]cachedResponse[Resp]),
+	}
+	return Respond(func(request Req) (Resp, error) {
+		if response, ok := cache.get(request); ok {
+			return response, nil
+		}
+		response, err := fn(request)
+		if err != nil {
+			return response, err
+		}
+		cache.put(request, response)
+		return response, nil
+	})
+}
@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespondCached(t *testing.T) {
+	reset()
+	calls := 0
+	RespondCached[lookupUserRequest, string](func(request lookupUserRequest) (string, error) {
+		calls++
+		return "user-" + strconv.Itoa(request.ID) + "-" + strconv.Itoa(calls), nil
+	}, time.Hour)
+
+	first, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.NoError(t, err)
+	second, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-42-1", first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, calls)
+
+	other, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-7-2", other)
+}
+
+func TestRespondCached_Expiry(t *testing.T) {
+	reset()
+	errUnavailable := errors.New("directory unavailable")
+	calls := 0
+	RespondCached[lookupUserRequest, string](func(request lookupUserRequest) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errUnavailable
+		}
+		return "user-" + strconv.Itoa(calls), nil
+	}, 20*time.Millisecond)
+
+	// Errors aren't cached
+	_, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.ErrorIs(t, err, errUnavailable)
+	resp, err := Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-2", resp)
+
+	time.Sleep(30 * time.Millisecond)
+	resp, err = Request[lookupUserRequest, string](lookupUserRequest{ID: 42})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-3", resp)
+}
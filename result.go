@@ -0,0 +1,91 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// ResultHandler is a type capable of handling events published through eventbus
+// that produces a result from handling the event. The results of ResultHandlers
+// can be collected when publishing with PublishReduce or PublishGather.
+type ResultHandler[T, R any] interface {
+	OnEvent(event T) (R, error)
+}
+
+type ResultHandlerFunc[T, R any] func(event T) (R, error)
+
+func (f ResultHandlerFunc[T, R]) OnEvent(event T) (R, error) {
+	return f(event)
+}
+
+// SubscribeResult registers a ResultHandler for a given type. When events are
+// published with Publish or PublishAsync the result of the handler is discarded,
+// while errors are treated the same as an ErrorHandler. The return value is a
+// subscription ID that can be used to unsubscribe the handler with Unsubscribe.
+func SubscribeResult[T, R any](handler ResultHandler[T, R]) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	id := subscribe(eventType, handler, func(event any) error {
+		_, err := handler.OnEvent(event.(T))
+		return err
+	}, 2)
+	entryByID(eventType, id).result = func(event any) (any, error) {
+		return handler.OnEvent(event.(T))
+	}
+	return id
+}
+
+// PublishReduce publishes an event like Publish and folds the results of the
+// ResultHandlers producing a result of type R into a single value, starting with
+// seed and invoking reduce with the accumulated value and the result of each
+// handler in the order Publish invokes the handlers, by priority and then in the
+// order they were registered unless rearranged with Reorder. ResultHandlers are
+// invoked synchronously even if they prefer DispatchAsync, see
+// DispatchPreferrer. Handlers that return an error don't contribute to the
+// result, and their errors are joined and returned along with the reduced value.
+// Handlers that don't produce a result of type R are invoked but don't
+// contribute to the result. When the event is
+// buffered because its type is paused seed is returned, and the handlers don't
+// contribute to the result once the event is delivered by Resume.
+func PublishReduce[T, R any](event T, seed R, reduce func(acc R, partial R) R) (R, error) {
+	acc := seed
+	err := publish(event, delivery{
+		onResult: func(result any, err error) {
+			if partial, ok := result.(R); ok && err == nil {
+				acc = reduce(acc, partial)
+			}
+		},
+	})
+	return acc, err
+}
+
+// PublishGather publishes an event like Publish and collects the results of the
+// ResultHandlers producing a result of type R, in the order Publish invokes the
+// handlers, like PublishReduce. Unlike PublishReduce every individual result is
+// preserved, one for each ResultHandler producing a result of type R, including
+// those preferring DispatchAsync, which are invoked synchronously. Handlers that
+// return an error contribute the zero value of R to the results, and their
+// errors are joined and returned along with the results. Handlers that don't
+// produce a result of type R are invoked but don't contribute to the results.
+// When the event is buffered because its type is paused no results are returned,
+// nor collected once the event is delivered by Resume.
+func PublishGather[T, R any](event T) ([]R, error) {
+	var results []R
+	err := publish(event, delivery{
+		onResult: func(result any, err error) {
+			partial, ok := result.(R)
+			// A nil result is the zero value of an interface type R.
+			if !ok && result != nil {
+				return
+			}
+			if err != nil {
+				partial = *new(R)
+			}
+			results = append(results, partial)
+		},
+	})
+	return results, err
+}
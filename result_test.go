@@ -0,0 +1,127 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type quoteRequestedEvent struct {
+	Amount int
+}
+
+func TestPublishReduce(t *testing.T) {
+	reset()
+	for _, n := range []int{1, 2, 3} {
+		n := n
+		SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+			return event.Amount * n, nil
+		}))
+	}
+
+	total, err := PublishReduce(quoteRequestedEvent{Amount: 10}, 0, func(acc, partial int) int {
+		return acc + partial
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 60, total)
+}
+
+func TestPublishReduce_Errors(t *testing.T) {
+	reset()
+	errUnavailable := errors.New("quote unavailable")
+	invoked := false
+	SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+		return 5, nil
+	}))
+	SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+		return 100, errUnavailable
+	}))
+	SubscribeResult[quoteRequestedEvent, string](ResultHandlerFunc[quoteRequestedEvent, string](func(event quoteRequestedEvent) (string, error) {
+		return "ignored", nil
+	}))
+	Subscribe[quoteRequestedEvent](HandlerFunc[quoteRequestedEvent](func(event quoteRequestedEvent) {
+		invoked = true
+	}))
+
+	total, err := PublishReduce(quoteRequestedEvent{Amount: 10}, 1, func(acc, partial int) int {
+		return acc + partial
+	})
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, 6, total)
+	assert.True(t, invoked)
+}
+
+func TestSubscribeResult_Publish(t *testing.T) {
+	reset()
+	errUnavailable := errors.New("quote unavailable")
+	SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+		return 0, errUnavailable
+	}))
+
+	assert.ErrorIs(t, Publish(quoteRequestedEvent{Amount: 10}), errUnavailable)
+}
+
+func TestPublishGather(t *testing.T) {
+	reset()
+	errUnavailable := errors.New("quote unavailable")
+	for _, n := range []int{1, 2, 3} {
+		n := n
+		SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+			if n == 2 {
+				return 100, errUnavailable
+			}
+			return event.Amount * n, nil
+		}))
+	}
+	SubscribeResult[quoteRequestedEvent, string](ResultHandlerFunc[quoteRequestedEvent, string](func(event quoteRequestedEvent) (string, error) {
+		return "ignored", nil
+	}))
+
+	results, err := PublishGather[quoteRequestedEvent, int](quoteRequestedEvent{Amount: 10})
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, []int{10, 0, 30}, results)
+}
+
+// asyncQuoteHandler is a ResultHandler preferring to be dispatched asynchronously.
+type asyncQuoteHandler struct {
+	factor int
+}
+
+func (h asyncQuoteHandler) OnEvent(event quoteRequestedEvent) (int, error) {
+	return event.Amount * h.factor, nil
+}
+
+func (h asyncQuoteHandler) DispatchPreference() DispatchMode {
+	return DispatchAsync
+}
+
+func TestPublishReduce_PrefersAsync(t *testing.T) {
+	reset()
+	SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+		return event.Amount, nil
+	}))
+	SubscribeResult[quoteRequestedEvent, int](asyncQuoteHandler{factor: 2})
+
+	// The result of the handler preferring DispatchAsync is still folded
+	total, err := PublishReduce(quoteRequestedEvent{Amount: 10}, 0, func(acc, partial int) int {
+		return acc + partial
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 30, total)
+	assert.Zero(t, ActiveAsyncGoroutines())
+}
+
+func TestPublishGather_Order(t *testing.T) {
+	reset()
+	first := SubscribeResult[quoteRequestedEvent, int](ResultHandlerFunc[quoteRequestedEvent, int](func(event quoteRequestedEvent) (int, error) {
+		return 1, nil
+	}))
+	second := SubscribeResult[quoteRequestedEvent, int](asyncQuoteHandler{factor: 2})
+	Reorder[quoteRequestedEvent]([]uint64{second, first})
+
+	// Results follow the dispatch order and include the async-preferring handler
+	results, err := PublishGather[quoteRequestedEvent, int](quoteRequestedEvent{Amount: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{20, 1}, results)
+}
@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// sampledHandler delivers a random sample of the events it receives to the
+// underlying handler.
+type sampledHandler[T any] struct {
+	handler Handler[T]
+	rate    float64
+}
+
+func (s *sampledHandler[T]) OnEvent(event T) {
+	if rand.Float64() < s.rate {
+		s.handler.OnEvent(event)
+	}
+}
+
+// SubscribeSampled registers a handler for a given type that receives roughly a
+// rate fraction of the events published, decided by a random draw for each
+// event, while other handlers still receive every event. This reduces the load
+// on expensive handlers, such as analytics, for high volume events where a sample
+// is sufficient. A rate of 1 or more delivers every event and a rate of 0 or less
+// delivers none. The return value is a subscription ID that can be used to
+// unsubscribe the handler.
+func SubscribeSampled[T any](handler Handler[T], rate float64) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	s := &sampledHandler[T]{
+		handler: handler,
+		rate:    rate,
+	}
+	return subscribe(reflect.TypeOf(*new(T)), s, handlerInvoker[T](s), 2)
+}
@@ -0,0 +1,42 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeSampled(t *testing.T) {
+	reset()
+	sampled, all := 0, 0
+	SubscribeSampled[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		sampled++
+	}), 0.1)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		all++
+	}))
+
+	for i := 0; i < 10000; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.Equal(t, 10000, all)
+	// The standard deviation of the sample is 30 events
+	assert.InDelta(t, 1000, sampled, 200)
+}
+
+func TestSubscribeSampled_Bounds(t *testing.T) {
+	reset()
+	never, always := 0, 0
+	SubscribeSampled[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		never++
+	}), 0)
+	SubscribeSampled[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		always++
+	}), 1)
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.Zero(t, never)
+	assert.Equal(t, 100, always)
+}
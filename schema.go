@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrIncompatibleSchema is reported when subscribing a handler whose schema
+// version the SchemaRegistry set with WithSchemaRegistry rejects.
+var ErrIncompatibleSchema = errors.New("eventbus: handler schema version is incompatible")
+
+// Versioned is implemented by handlers that declare the schema version of the
+// event type they were built against, which is checked against the
+// SchemaRegistry set with WithSchemaRegistry when they are subscribed.
+type Versioned interface {
+	SchemaVersion() string
+}
+
+// SchemaRegistry is consulted when a handler implementing Versioned is
+// subscribed, set with WithSchemaRegistry. Compatible returns an error if a
+// handler built against the given schema version of the event type can't handle
+// the events of the type currently published.
+type SchemaRegistry interface {
+	Compatible(eventType reflect.Type, version string) error
+}
+
+// checkSchema returns an error wrapping ErrIncompatibleSchema if the handler is
+// Versioned and the schema registry rejects its version. The caller must hold
+// the lock.
+func checkSchema(eventType reflect.Type, handler any) error {
+	v, ok := handler.(Versioned)
+	if !ok || cfg.schemaRegistry == nil {
+		return nil
+	}
+	version := v.SchemaVersion()
+	if err := cfg.schemaRegistry.Compatible(eventType, version); err != nil {
+		return fmt.Errorf("%w for event %s: handler version %s: %w", ErrIncompatibleSchema, typeName(eventType), version, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticSchemaRegistry map[reflect.Type]string
+
+func (r staticSchemaRegistry) Compatible(eventType reflect.Type, version string) error {
+	if r[eventType] != version {
+		return fmt.Errorf("registered version is %s", r[eventType])
+	}
+	return nil
+}
+
+type versionedHandler struct {
+	version string
+}
+
+func (h versionedHandler) OnEvent(event userCreatedEvent) {}
+
+func (h versionedHandler) SchemaVersion() string {
+	return h.version
+}
+
+func TestWithSchemaRegistry(t *testing.T) {
+	reset()
+	Configure(WithSchemaRegistry(staticSchemaRegistry{
+		reflect.TypeOf(userCreatedEvent{}): "v2",
+	}))
+
+	assert.NotZero(t, Subscribe[userCreatedEvent](versionedHandler{version: "v2"}))
+	// Handlers that don't declare a version aren't checked
+	assert.NotZero(t, Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {})))
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			assert.ErrorIs(t, err, ErrIncompatibleSchema)
+			assert.EqualError(t, err, "eventbus: handler schema version is incompatible for event userCreatedEvent: handler version v1: registered version is v2")
+		}()
+		Subscribe[userCreatedEvent](versionedHandler{version: "v1"})
+	}()
+	assert.Len(t, Subscriptions[userCreatedEvent](), 2)
+}
+
+func TestWithSchemaRegistry_Register(t *testing.T) {
+	reset()
+	Configure(WithSchemaRegistry(staticSchemaRegistry{
+		reflect.TypeOf(userCreatedEvent{}): "v2",
+	}))
+
+	ids, err := Register(func(r *Registrar) {
+		r.Add(On[userCreatedEvent](versionedHandler{version: "v2"}))
+		r.Add(On[userCreatedEvent](versionedHandler{version: "v1"}))
+	})
+	assert.ErrorIs(t, err, ErrIncompatibleSchema)
+	assert.Nil(t, ids)
+	assert.Empty(t, Subscriptions[userCreatedEvent]())
+}
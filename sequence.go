@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type sequenceKey struct{}
+
+// sequence is the last sequence number stamped onto a publish with
+// WithSequenceNumbers.
+var sequence atomic.Uint64
+
+// Sequence returns the sequence number of the event being handled by a
+// ContextHandler, which is passed the context, and whether there is one. Events
+// are only numbered when WithSequenceNumbers is configured.
+func Sequence(ctx context.Context) (uint64, bool) {
+	seq, ok := ctx.Value(sequenceKey{}).(uint64)
+	return seq, ok
+}
+
+// sequencer serializes the dispatch of numbered events, so events reach their
+// handlers in the order of their sequence numbers. It is held by the goroutine
+// dispatching an event until the event has been dispatched, and handlers
+// publishing events while it is held are dispatched by the same goroutine, so a
+// goroutine already holding it doesn't acquire it again.
+var sequencer struct {
+	mu    sync.Mutex
+	owner atomic.Uint64
+}
+
+// sequenced stamps the next sequence number onto the delivery if sequence
+// numbers are enabled and there are handlers registered for the event type to
+// observe it, returning the function to call once the event has been dispatched.
+// The number is assigned holding the sequencer, which is waited for with the
+// read lock released, and release hands it to the next event, so no other
+// numbered event is dispatched in the meantime. The caller must hold the read
+// lock.
+func (d delivery) sequenced(eventType reflect.Type) (delivery, func()) {
+	if !cfg.sequenceNumbers || len(handlers[eventType]) == 0 {
+		return d, func() {}
+	}
+
+	release := func() {}
+	if id := goroutineID(); sequencer.owner.Load() != id {
+		unlocked(sequencer.mu.Lock)
+		sequencer.owner.Store(id)
+		release = func() {
+			sequencer.owner.Store(0)
+			sequencer.mu.Unlock()
+		}
+	}
+	// The handlers may have been unsubscribed while waiting for the sequencer.
+	if !cfg.sequenceNumbers || len(handlers[eventType]) == 0 {
+		release()
+		return d, func() {}
+	}
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d.ctx = context.WithValue(ctx, sequenceKey{}, sequence.Add(1))
+	return d, release
+}
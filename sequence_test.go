@@ -0,0 +1,139 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSequenceNumbers(t *testing.T) {
+	reset()
+	Configure(WithSequenceNumbers())
+
+	var sequences []uint64
+	record := func(ctx context.Context) {
+		seq, ok := Sequence(ctx)
+		assert.True(t, ok)
+		sequences = append(sequences, seq)
+	}
+	SubscribeContext[userCreatedEvent](ContextHandlerFunc[userCreatedEvent](func(ctx context.Context, event userCreatedEvent) error {
+		record(ctx)
+		return nil
+	}))
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		record(ctx)
+		return nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+		// Events without handlers don't use up a sequence number
+		assert.Error(t, Publish(orderEvent{Seq: i}))
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, sequences)
+}
+
+func TestWithSequenceNumbers_PublishAsync(t *testing.T) {
+	reset()
+	Configure(WithSequenceNumbers())
+
+	var mu sync.Mutex
+	var sequences []uint64
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		seq, _ := Sequence(ctx)
+		mu.Lock()
+		defer mu.Unlock()
+		sequences = append(sequences, seq)
+		return nil
+	}))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0
+	}, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []uint64{1, 2, 3, 4, 5}, sequences)
+}
+
+func TestSequence_Disabled(t *testing.T) {
+	reset()
+	numbered := true
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		_, numbered = Sequence(ctx)
+		return nil
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.False(t, numbered)
+}
+
+func TestWithSequenceNumbers_Concurrent(t *testing.T) {
+	reset()
+	Configure(WithSequenceNumbers())
+
+	var mu sync.Mutex
+	var sequences []uint64
+	record := func(ctx context.Context) error {
+		seq, _ := Sequence(ctx)
+		// Give concurrent publishes the chance to overtake this one
+		time.Sleep(time.Microsecond)
+		mu.Lock()
+		defer mu.Unlock()
+		sequences = append(sequences, seq)
+		return nil
+	}
+	SubscribeContext[userCreatedEvent](ContextHandlerFunc[userCreatedEvent](func(ctx context.Context, event userCreatedEvent) error {
+		return record(ctx)
+	}))
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		return record(ctx)
+	}))
+
+	// Events published concurrently reach the handlers in sequence order
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				if i%2 == 0 {
+					assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+				} else {
+					assert.NoError(t, Publish(progressEvent{Count: j}))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, sequences, 200)
+	for i, seq := range sequences {
+		assert.Equal(t, uint64(i+1), seq)
+	}
+}
+
+func TestWithSequenceNumbers_PublishFromHandler(t *testing.T) {
+	reset()
+	Configure(WithSequenceNumbers())
+
+	var sequences []uint64
+	SubscribeContext[userCreatedEvent](ContextHandlerFunc[userCreatedEvent](func(ctx context.Context, event userCreatedEvent) error {
+		seq, _ := Sequence(ctx)
+		sequences = append(sequences, seq)
+		return Publish(progressEvent{Count: 1})
+	}))
+	SubscribeContext[progressEvent](ContextHandlerFunc[progressEvent](func(ctx context.Context, event progressEvent) error {
+		seq, _ := Sequence(ctx)
+		sequences = append(sequences, seq)
+		return nil
+	}))
+
+	// The nested publish doesn't wait for the sequencer its publisher holds
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.Equal(t, []uint64{1, 2}, sequences)
+}
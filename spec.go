@@ -0,0 +1,101 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrUnknownHandler is returned by LoadSubscriptions when a SubscriptionSpec names
+// a handler that hasn't been registered with RegisterHandlerFactory.
+var ErrUnknownHandler = errors.New("eventbus: unknown handler")
+
+// SubscriptionSpec declares a subscription by the name of the event type and the
+// identifier of the handler, so subscriptions can be wired from configuration,
+// such as a JSON file, with LoadSubscriptions.
+type SubscriptionSpec struct {
+	// Type is the name of the event type given by the TypeNamer configured with
+	// WithTypeNamer.
+	Type string `json:"type"`
+	// Handler is the identifier the factory of the handler was registered with
+	// using RegisterHandlerFactory.
+	Handler string `json:"handler"`
+}
+
+// handlerFactory creates a handler for events of the type.
+type handlerFactory struct {
+	eventType reflect.Type
+	create    func() Registration
+}
+
+// handlerFactories holds the factories registered with RegisterHandlerFactory by
+// the identifier of their handler.
+var handlerFactories = make(map[string]handlerFactory)
+
+// RegisterHandlerFactory registers a factory creating a handler for events of
+// type T under an identifier SubscriptionSpecs refer to the handler by. Each
+// subscription loaded with LoadSubscriptions naming the identifier subscribes a
+// new handler created by the factory. Registering another factory under the same
+// identifier replaces the previous factory.
+func RegisterHandlerFactory[T any](handler string, factory func() Handler[T]) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	handlerFactories[handler] = handlerFactory{
+		eventType: reflect.TypeOf(*new(T)),
+		create: func() Registration {
+			return On[T](factory())
+		},
+	}
+}
+
+// LoadSubscriptions subscribes a handler for each of the specs, created by the
+// factory registered for its handler with RegisterHandlerFactory, which decouples
+// wiring handlers from code. The handlers are subscribed as a single transaction
+// with Register, named by the identifier of their handler so they can be removed
+// with UnsubscribeWhere. If a spec names a handler without a factory an error
+// wrapping ErrUnknownHandler is returned, and if it names an event type other
+// than the type the handler handles an error is returned, in which case none of
+// the handlers are subscribed.
+//
+//	eventbus.RegisterHandlerFactory("audit", func() eventbus.Handler[UserCreated] {
+//		return newAuditHandler(db)
+//	})
+//	err := eventbus.LoadSubscriptions([]eventbus.SubscriptionSpec{
+//		{Type: "UserCreated", Handler: "audit"},
+//	})
+func LoadSubscriptions(config []SubscriptionSpec) error {
+	mu.RLock()
+	factories := make([]handlerFactory, 0, len(config))
+	var err error
+	for _, spec := range config {
+		factory, ok := handlerFactories[spec.Handler]
+		if !ok {
+			err = fmt.Errorf("%w %q", ErrUnknownHandler, spec.Handler)
+			break
+		}
+		if name := typeName(factory.eventType); name != spec.Type {
+			err = fmt.Errorf("eventbus: handler %q handles event %s, not %s", spec.Handler, name, spec.Type)
+			break
+		}
+		factories = append(factories, factory)
+	}
+	mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// The factories are invoked without holding the lock so they can use the bus
+	registrations := make([]Registration, 0, len(factories))
+	for i, factory := range factories {
+		reg := factory.create()
+		reg.name = config[i].Handler
+		registrations = append(registrations, reg)
+	}
+	_, err = Register(func(r *Registrar) {
+		for _, reg := range registrations {
+			r.Add(reg)
+		}
+	})
+	return err
+}
@@ -0,0 +1,50 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSubscriptions(t *testing.T) {
+	reset()
+	var received []progressEvent
+	created := 0
+	RegisterHandlerFactory("progress", func() Handler[progressEvent] {
+		created++
+		return HandlerFunc[progressEvent](func(event progressEvent) {
+			received = append(received, event)
+		})
+	})
+
+	assert.NoError(t, LoadSubscriptions([]SubscriptionSpec{
+		{Type: "progressEvent", Handler: "progress"},
+	}))
+	assert.Equal(t, 1, created)
+	subscriptions := Subscriptions[progressEvent]()
+	assert.Len(t, subscriptions, 1)
+	assert.Equal(t, "progress", subscriptions[0].Name)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.Equal(t, []progressEvent{{Count: 1}}, received)
+}
+
+func TestLoadSubscriptions_Invalid(t *testing.T) {
+	reset()
+	RegisterHandlerFactory("progress", func() Handler[progressEvent] {
+		return HandlerFunc[progressEvent](func(progressEvent) {})
+	})
+
+	err := LoadSubscriptions([]SubscriptionSpec{
+		{Type: "progressEvent", Handler: "progress"},
+		{Type: "orderEvent", Handler: "orders"},
+	})
+	assert.ErrorIs(t, err, ErrUnknownHandler)
+	assert.Empty(t, Subscriptions[progressEvent]())
+
+	err = LoadSubscriptions([]SubscriptionSpec{
+		{Type: "orderEvent", Handler: "progress"},
+	})
+	assert.EqualError(t, err, `eventbus: handler "progress" handles event progressEvent, not orderEvent`)
+	assert.Empty(t, Subscriptions[progressEvent]())
+}
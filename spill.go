@@ -0,0 +1,80 @@
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SpilledInvocation describes an invocation of a handler spilled by the worker
+// pool to a SpillStore. A store which persists invocations outside of memory is
+// responsible for encoding the event, for example using JSON with the type names
+// registered with RegisterJSON.
+type SpilledInvocation struct {
+	// SubscriptionID is the subscription ID of the handler to invoke.
+	SubscriptionID uint64
+	// EventType is the type of the event.
+	EventType reflect.Type
+	// Event is the event to invoke the handler with.
+	Event any
+}
+
+// SpillStore is a FIFO store of handler invocations the worker pool spills to
+// when its queue is full, configured with WithSpillover. The worker pool never
+// calls the store concurrently and only calls Pop when it has pushed more
+// invocations than it has popped.
+type SpillStore interface {
+	// Push appends the invocation to the store.
+	Push(invocation SpilledInvocation) error
+	// Pop removes and returns the oldest invocation in the store.
+	Pop() (SpilledInvocation, error)
+}
+
+func (p *workerPool) setStore(store SpillStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.store = store
+}
+
+// spillable describes the invocation of the handler with the subscription ID if
+// invocations can be spilled, otherwise it returns nil. Invocations are never
+// spilled under WithBulkheads since the store is shared by every event type. The
+// caller must hold the read lock.
+func spillable(eventType reflect.Type, id uint64, event any) *SpilledInvocation {
+	if pool == nil || cfg.spillStore == nil || cfg.bulkheads {
+		return nil
+	}
+	return &SpilledInvocation{SubscriptionID: id, EventType: eventType, Event: event}
+}
+
+// runSpilled invokes the handler of an invocation popped from the spill store
+// like submitEntry would have, with its panics recovered and its invocation
+// measured. Invocations of handlers that have since been unsubscribed are
+// dropped.
+func runSpilled(inv SpilledInvocation, err error) {
+	dequeued(inv.EventType)
+	defer typeInflight(inv.EventType).done()
+
+	mu.RLock()
+	callback := cfg.errorCallback
+	var invoke func(event any) error
+	if err == nil {
+		if e := entryByID(inv.EventType, inv.SubscriptionID); e != nil {
+			invoke = delivery{}.asyncInvoker(inv.EventType, typeName(inv.EventType), *e)
+		}
+	}
+	mu.RUnlock()
+
+	if err != nil {
+		if callback != nil {
+			callback(fmt.Errorf("eventbus: popping spilled handler invocation: %w", err))
+		}
+		return
+	}
+	if invoke == nil {
+		return
+	}
+	if err := invoke(inv.Event); err != nil && callback != nil {
+		callback(err)
+	}
+}
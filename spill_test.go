@@ -0,0 +1,152 @@
+package eventbus
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSpillStore struct {
+	mu          sync.Mutex
+	invocations []SpilledInvocation
+	pushed      int
+	err         error
+}
+
+func (s *fakeSpillStore) Push(invocation SpilledInvocation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.invocations = append(s.invocations, invocation)
+	s.pushed++
+	return nil
+}
+
+func (s *fakeSpillStore) Pop() (SpilledInvocation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invocation := s.invocations[0]
+	s.invocations = s.invocations[1:]
+	return invocation, nil
+}
+
+func (s *fakeSpillStore) counts() (pushed, stored int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pushed, len(s.invocations)
+}
+
+func TestWithSpillover(t *testing.T) {
+	reset()
+	store := &fakeSpillStore{}
+	Configure(WithWorkerPool(1, 1), WithSpillover(store))
+
+	// Wedge the only worker so invocations queue up behind it
+	release := make(chan struct{})
+	started := make(chan struct{})
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		close(started)
+		<-release
+	}))
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	<-started
+
+	var mu sync.Mutex
+	var received []int
+	id := Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.Count)
+	}))
+
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	// The first invocation fills the queue, the rest are spilled
+	pushed, stored := store.counts()
+	assert.Equal(t, 4, pushed)
+	assert.Equal(t, 4, stored)
+	assert.Equal(t, SpilledInvocation{SubscriptionID: id, EventType: TypeOf[progressEvent](), Event: progressEvent{Count: 2}}, store.invocations[0])
+	assert.Empty(t, ShedCounts())
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 5
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, received)
+	_, stored = store.counts()
+	assert.Zero(t, stored)
+}
+
+func TestWithSpillover_PushError(t *testing.T) {
+	reset()
+	errStore := errors.New("disk full")
+	store := &fakeSpillStore{err: errStore}
+	Configure(WithWorkerPool(1, 1), WithSpillover(store))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		close(started)
+		<-release
+	}))
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	<-started
+	defer close(release)
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.ErrorIs(t, PublishAsync(progressEvent{Count: 2}), errStore)
+}
+
+func TestWithSpillover_Panic(t *testing.T) {
+	reset()
+	store := &fakeSpillStore{}
+	var mu sync.Mutex
+	var errs []error
+	Configure(WithWorkerPool(1, 1), WithSpillover(store), WithPanicEvents(), WithErrorCallback(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(userCreatedEvent) {
+		close(started)
+		<-release
+	}))
+	assert.NoError(t, PublishAsync(userCreatedEvent{Name: "John Doe"}))
+	<-started
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		panic("boom")
+	}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 1}))
+	assert.NoError(t, PublishAsync(progressEvent{Count: 2}))
+	pushed, _ := store.counts()
+	assert.Equal(t, 1, pushed)
+
+	// The panic of the spilled invocation is recovered like the queued one
+	close(release)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) == 2
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	for _, err := range errs {
+		assert.ErrorIs(t, err, ErrHandlerPanic)
+	}
+}
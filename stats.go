@@ -0,0 +1,43 @@
+package eventbus
+
+import (
+	"sync/atomic"
+)
+
+// SubscriptionStats is a point-in-time snapshot of the subscription counters
+// maintained by eventbus. A growing gap between Subscribes and Unsubscribes
+// over time is often an indication handlers are being leaked.
+type SubscriptionStats struct {
+	// Subscribes is the total number of subscriptions created.
+	Subscribes uint64
+	// Unsubscribes is the total number of subscriptions successfully removed.
+	Unsubscribes uint64
+	// Active is the number of subscriptions currently registered.
+	Active uint64
+}
+
+var (
+	subscribeCount   uint64 = 0
+	unsubscribeCount uint64 = 0
+	activeCount      uint64 = 0
+)
+
+// Stats returns a snapshot of the subscription counters. The counters are
+// maintained atomically and reading them does not acquire any locks.
+func Stats() SubscriptionStats {
+	return SubscriptionStats{
+		Subscribes:   atomic.LoadUint64(&subscribeCount),
+		Unsubscribes: atomic.LoadUint64(&unsubscribeCount),
+		Active:       atomic.LoadUint64(&activeCount),
+	}
+}
+
+func recordSubscribe() {
+	atomic.AddUint64(&subscribeCount, 1)
+	atomic.AddUint64(&activeCount, 1)
+}
+
+func recordUnsubscribe() {
+	atomic.AddUint64(&unsubscribeCount, 1)
+	atomic.AddUint64(&activeCount, ^uint64(0))
+}
@@ -0,0 +1,30 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	reset()
+	assert.Equal(t, SubscriptionStats{}, Stats())
+
+	id1 := Subscribe[userCreatedEvent](new(userCreatedHandler))
+	id2 := Subscribe[userCreatedEvent](new(userCreatedHandler))
+	fid := SubscribeFallback(func(event any) {})
+	assert.Equal(t, SubscriptionStats{Subscribes: 3, Unsubscribes: 0, Active: 3}, Stats())
+
+	assert.True(t, Unsubscribe[userCreatedEvent](id1))
+	assert.True(t, UnsubscribeFallback(fid))
+	assert.Equal(t, SubscriptionStats{Subscribes: 3, Unsubscribes: 2, Active: 1}, Stats())
+
+	// Failed unsubscribes must not affect the counters
+	assert.False(t, Unsubscribe[userCreatedEvent](id1))
+	assert.False(t, Unsubscribe[string](id2))
+	assert.False(t, UnsubscribeFallback(fid))
+	assert.Equal(t, SubscriptionStats{Subscribes: 3, Unsubscribes: 2, Active: 1}, Stats())
+
+	assert.True(t, Unsubscribe[userCreatedEvent](id2))
+	assert.Equal(t, SubscriptionStats{Subscribes: 3, Unsubscribes: 3, Active: 0}, Stats())
+}
@@ -0,0 +1,51 @@
+//go:build go1.23
+
+package eventbus
+
+import (
+	"context"
+	"iter"
+)
+
+// streamBufferSize is the number of events a stream buffers before publishing
+// blocks waiting on the consumer.
+const streamBufferSize = 64
+
+// Stream returns an iterator over events of the given type. The handler backing
+// the stream is subscribed when iteration begins and is unsubscribed when the
+// loop exits, either because the context was cancelled or the loop body broke
+// out of the loop. Events published before iteration begins are not observed.
+//
+//	for event := range eventbus.Stream[UserCreated](ctx) {
+//		// do something with event
+//	}
+func Stream[T any](ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		events := make(chan T, streamBufferSize)
+		done := make(chan struct{})
+		id := Subscribe[T](HandlerFunc[T](func(event T) {
+			select {
+			case events <- event:
+			case <-done:
+			}
+		}))
+		defer func() {
+			// Nothing reads the stream once iteration ends, so publishers waiting
+			// for room in its buffer, including those that read the handlers
+			// before Unsubscribe, would otherwise wait forever.
+			close(done)
+			Unsubscribe[T](id)
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				if !yield(event) {
+					return
+				}
+			}
+		}
+	}
+}
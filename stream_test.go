@@ -0,0 +1,59 @@
+//go:build go1.23
+
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan progressEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range Stream[progressEvent](ctx) {
+			received <- event
+		}
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(Subscriptions[progressEvent]()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.Equal(t, progressEvent{Count: 1}, <-received)
+	assert.Equal(t, progressEvent{Count: 2}, <-received)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for stream to exit")
+	}
+	assert.Empty(t, Subscriptions[progressEvent]())
+}
+
+func TestStream_Break(t *testing.T) {
+	reset()
+	go func() {
+		assert.Eventually(t, func() bool {
+			return len(Subscriptions[progressEvent]()) == 1
+		}, time.Second, 10*time.Millisecond)
+		_ = Publish(progressEvent{Count: 1})
+	}()
+
+	for event := range Stream[progressEvent](context.Background()) {
+		assert.Equal(t, progressEvent{Count: 1}, event)
+		break
+	}
+	assert.Empty(t, Subscriptions[progressEvent]())
+}
@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// Suspend temporarily disables the handler with the given subscription ID for the
+// specified type. A suspended handler remains registered but is skipped when
+// events are published until it is reactivated with Unsuspend. If the handler is
+// not found, it returns false.
+func Suspend[T any](subscriptionID uint64) bool {
+	return setSuspended(reflect.TypeOf(*new(T)), subscriptionID, true)
+}
+
+// Unsuspend reactivates a handler previously suspended with Suspend so it is
+// invoked for published events again. If the handler is not found, it returns
+// false.
+func Unsuspend[T any](subscriptionID uint64) bool {
+	return setSuspended(reflect.TypeOf(*new(T)), subscriptionID, false)
+}
+
+func setSuspended(eventType reflect.Type, subscriptionID uint64, suspended bool) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries := handlers[eventType]
+	for i := range entries {
+		if entries[i].id == subscriptionID {
+			entries[i].suspended = suspended
+			return true
+		}
+	}
+	return false
+}
+
+// active reports whether the handler of the entry can be invoked, which it can't
+// while it is suspended, disabled by its gate or being removed for panicking.
+func (h handlerEntry) active() bool {
+	return !h.suspended && !h.gated() && !h.panics.removed()
+}
+
+// activeEntries returns the active entries. If every entry is active the entries
+// are returned as is without allocating.
+func activeEntries(entries []handlerEntry) []handlerEntry {
+	for i, h := range entries {
+		if h.active() {
+			continue
+		}
+		active := make([]handlerEntry, 0, len(entries)-1)
+		active = append(active, entries[:i]...)
+		for _, h := range entries[i+1:] {
+			if h.active() {
+				active = append(active, h)
+			}
+		}
+		return active
+	}
+	return entries
+}
@@ -0,0 +1,52 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspend(t *testing.T) {
+	reset()
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	h1 := new(userCreatedHandler)
+	h1.On("OnEvent", event).Return()
+	h2 := new(userCreatedHandler)
+	h2.On("OnEvent", event).Return()
+	id1 := Subscribe[userCreatedEvent](h1)
+	Subscribe[userCreatedEvent](h2)
+
+	assert.True(t, Suspend[userCreatedEvent](id1))
+	assert.NoError(t, Publish(event))
+	h1.AssertNumberOfCalls(t, "OnEvent", 0)
+	h2.AssertNumberOfCalls(t, "OnEvent", 1)
+
+	assert.True(t, Unsuspend[userCreatedEvent](id1))
+	assert.NoError(t, Publish(event))
+	h1.AssertNumberOfCalls(t, "OnEvent", 1)
+	h2.AssertNumberOfCalls(t, "OnEvent", 2)
+}
+
+func TestSuspend_NotFound(t *testing.T) {
+	reset()
+	assert.False(t, Suspend[userCreatedEvent](1))
+	assert.False(t, Unsuspend[userCreatedEvent](1))
+}
+
+func TestSuspend_PublishBalanced(t *testing.T) {
+	reset()
+	counts := make([]int, 3)
+	ids := make([]uint64, 3)
+	for i := range counts {
+		i := i
+		ids[i] = Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+			counts[i]++
+		}))
+	}
+	Suspend[userCreatedEvent](ids[1])
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, PublishBalanced(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	}
+	assert.Equal(t, []int{5, 0, 5}, counts)
+}
@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// threadCall is an event waiting to be handled on a thread bound handler's
+// goroutine, with a channel which is closed once it has been handled.
+type threadCall[T any] struct {
+	event   T
+	handled chan struct{}
+}
+
+// threadHandler invokes a handler on a single dedicated goroutine which is locked
+// to an OS thread.
+type threadHandler[T any] struct {
+	calls chan threadCall[T]
+	done  chan struct{}
+}
+
+func (h *threadHandler[T]) OnEvent(event T) {
+	call := threadCall[T]{event: event, handled: make(chan struct{})}
+	select {
+	case h.calls <- call:
+	case <-h.done:
+		return
+	}
+	select {
+	case <-call.handled:
+	case <-h.done:
+	}
+}
+
+func (h *threadHandler[T]) stop() {
+	close(h.done)
+}
+
+func (h *threadHandler[T]) run(handler Handler[T]) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for {
+		select {
+		case call := <-h.calls:
+			handler.OnEvent(call.event)
+			close(call.handled)
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// SubscribeOnThread registers a handler for a given type which is always invoked
+// on the same dedicated goroutine, locked to its own OS thread with
+// runtime.LockOSThread, regardless of which goroutine publishes the event. This
+// is needed for handlers that use thread affine resources, such as UI toolkits
+// or C libraries called through cgo, which must always be called from the same
+// thread. Publishing still waits for the handler to handle the event, so events
+// are handled one at a time in the order they are published. The handler must
+// not publish an event it handles itself, as the handler's goroutine would wait
+// on itself. The return value is a subscription ID that can be used to
+// unsubscribe the handler, which also stops the handler's goroutine and releases
+// its thread.
+func SubscribeOnThread[T any](handler Handler[T]) uint64 {
+	mustNotBeNil(handler)
+
+	h := &threadHandler[T]{
+		calls: make(chan threadCall[T]),
+		done:  make(chan struct{}),
+	}
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	// The goroutine is started once subscribing succeeded, so neither it nor its
+	// thread is leaked if it panics. Publishing waits for it to start.
+	id := subscribe(reflect.TypeOf(*new(T)), h, handlerInvoker[T](h), 2)
+	go h.run(handler)
+	return id
+}
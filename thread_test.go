@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeOnThread(t *testing.T) {
+	reset()
+	var mu sync.Mutex
+	goroutines := make(map[uint64]bool)
+	var received []int
+	id := SubscribeOnThread[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		goroutines[goroutineID()] = true
+		received = append(received, event.Count)
+	}))
+
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	mu.Lock()
+	assert.Equal(t, []int{1}, received)
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(count int) {
+			defer wg.Done()
+			assert.NoError(t, Publish(progressEvent{Count: count}))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, received, 11)
+	assert.Len(t, goroutines, 1)
+	assert.False(t, goroutines[goroutineID()])
+
+	assert.True(t, Unsubscribe[progressEvent](id))
+}
+
+func TestSubscribeOnThread_SubscribePanics(t *testing.T) {
+	reset()
+	Configure(WithMaxHandlers(1))
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	// The handler's goroutine isn't left running when subscribing panics
+	goroutines := runtime.NumGoroutine()
+	assert.Panics(t, func() {
+		SubscribeOnThread[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+	})
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// timesHandler delivers events to the underlying handler a limited number of
+// times, unsubscribing itself after the last delivery.
+type timesHandler[T any] struct {
+	handler   Handler[T]
+	id        uint64
+	remaining atomic.Int64
+}
+
+func (h *timesHandler[T]) OnEvent(event T) {
+	remaining := h.remaining.Add(-1)
+	if remaining < 0 {
+		return
+	}
+	h.handler.OnEvent(event)
+	if remaining == 0 {
+		Unsubscribe[T](h.id)
+	}
+}
+
+// SubscribeTimes registers a handler for a given type that is invoked for at most
+// the first n events published, such as to handle the first few retries and then
+// stop, after which it unsubscribes itself. The count is safe with respect to
+// concurrent dispatch, so the handler is never invoked more than n times even
+// with PublishAsync, and the handler is unsubscribed immediately after its nth
+// invocation returns. The return value is a subscription ID that can be used to
+// unsubscribe the handler early with Unsubscribe.
+func SubscribeTimes[T any](handler Handler[T], n int) uint64 {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	h := &timesHandler[T]{handler: handler}
+	h.remaining.Store(int64(n))
+	h.id = subscribe(reflect.TypeOf(*new(T)), h, handlerInvoker[T](h), 2)
+	return h.id
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeTimes(t *testing.T) {
+	reset()
+	var invoked atomic.Int32
+	SubscribeTimes[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		invoked.Add(1)
+	}), 3)
+	// Keep a handler subscribed so publishing doesn't fail once the handler is gone
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, Publish(progressEvent{Count: i}))
+	}
+	assert.Equal(t, int32(3), invoked.Load())
+	assert.Eventually(t, func() bool {
+		return len(Subscriptions[progressEvent]()) == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestSubscribeTimes_PublishAsync(t *testing.T) {
+	reset()
+	var invoked atomic.Int32
+	SubscribeTimes[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {
+		invoked.Add(1)
+	}), 3)
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, PublishAsync(progressEvent{Count: i}))
+	}
+	assert.Eventually(t, func() bool {
+		return ActiveAsyncGoroutines() == 0 && len(Subscriptions[progressEvent]()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(3), invoked.Load())
+}
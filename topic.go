@@ -0,0 +1,52 @@
+package eventbus
+
+// Publisher publishes events of type T. It allows code publishing events to
+// depend on an interface which can be replaced with a fake in tests, such as the
+// one provided by the eventbustest package, rather than the package functions.
+type Publisher[T any] interface {
+	Publish(event T) error
+	PublishAsync(event T) error
+}
+
+// Subscriber subscribes handlers to events of type T. It allows code subscribing
+// to events to depend on an interface which can be replaced with a fake in tests.
+type Subscriber[T any] interface {
+	Subscribe(handler Handler[T]) uint64
+	Unsubscribe(subscriptionID uint64) bool
+}
+
+// Topic publishes and subscribes to events of type T.
+type Topic[T any] interface {
+	Publisher[T]
+	Subscriber[T]
+}
+
+type topic[T any] struct{}
+
+// TopicOf returns a Topic for events of type T backed by the package functions
+// Publish, PublishAsync, Subscribe and Unsubscribe.
+//
+//	type Signup struct {
+//		events eventbus.Publisher[UserCreated]
+//	}
+//
+//	signup := Signup{events: eventbus.TopicOf[UserCreated]()}
+func TopicOf[T any]() Topic[T] {
+	return topic[T]{}
+}
+
+func (topic[T]) Publish(event T) error {
+	return Publish(event)
+}
+
+func (topic[T]) PublishAsync(event T) error {
+	return PublishAsync(event)
+}
+
+func (topic[T]) Subscribe(handler Handler[T]) uint64 {
+	return Subscribe(handler)
+}
+
+func (topic[T]) Unsubscribe(subscriptionID uint64) bool {
+	return Unsubscribe[T](subscriptionID)
+}
@@ -0,0 +1,23 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicOf(t *testing.T) {
+	reset()
+
+	var received []userCreatedEvent
+	topic := TopicOf[userCreatedEvent]()
+	id := topic.Subscribe(HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {
+		received = append(received, event)
+	}))
+
+	assert.NoError(t, topic.Publish(userCreatedEvent{Name: "John Doe"}))
+	assert.NoError(t, Publish(userCreatedEvent{Name: "Jane Doe"}))
+	assert.True(t, topic.Unsubscribe(id))
+	assert.Error(t, topic.Publish(userCreatedEvent{Name: "Jim Doe"}))
+	assert.Equal(t, []userCreatedEvent{{Name: "John Doe"}, {Name: "Jane Doe"}}, received)
+}
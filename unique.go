@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"reflect"
+)
+
+// SubscribeUnique registers a handler for a given type only if the same handler
+// is not already registered for that type. This makes registration idempotent,
+// which is useful when the code subscribing handlers may run more than once.
+// Handlers are considered the same if they are equal, which for pointers means
+// they point to the same value. Handlers of a type that isn't comparable, such
+// as HandlerFunc, can't be compared and are always registered. The return values
+// are the subscription ID of the new or existing registration and whether the
+// handler was newly registered.
+func SubscribeUnique[T any](handler Handler[T]) (uint64, bool) {
+	mustNotBeNil(handler)
+
+	mu.Lock()
+	defer unlockAndNotify()
+
+	eventType := reflect.TypeOf(*new(T))
+	if reflect.TypeOf(handler).Comparable() {
+		for _, h := range handlers[eventType] {
+			if existing, ok := h.handler.(Handler[T]); ok && sameHandler(existing, handler) {
+				return h.id, false
+			}
+		}
+	}
+	return subscribe(eventType, handler, handlerInvoker(handler), 2), true
+}
+
+// sameHandler reports whether two handlers are equal without panicking when the
+// dynamic type of the existing handler isn't comparable.
+func sameHandler[T any](existing, handler Handler[T]) bool {
+	if reflect.TypeOf(existing) != reflect.TypeOf(handler) {
+		return false
+	}
+	return existing == handler
+}
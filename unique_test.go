@@ -0,0 +1,34 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeUnique(t *testing.T) {
+	reset()
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}).Return()
+
+	id1, added := SubscribeUnique[userCreatedEvent](h)
+	assert.True(t, added)
+	id2, added := SubscribeUnique[userCreatedEvent](h)
+	assert.False(t, added)
+	assert.Equal(t, id1, id2)
+	assert.Len(t, Subscriptions[userCreatedEvent](), 1)
+
+	assert.NoError(t, Publish(userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestSubscribeUnique_DifferentHandlers(t *testing.T) {
+	reset()
+	Subscribe[userCreatedEvent](HandlerFunc[userCreatedEvent](func(event userCreatedEvent) {}))
+
+	_, added := SubscribeUnique[userCreatedEvent](new(userCreatedHandler))
+	assert.True(t, added)
+	_, added = SubscribeUnique[userCreatedEvent](new(userCreatedHandler))
+	assert.True(t, added)
+	assert.Len(t, Subscriptions[userCreatedEvent](), 3)
+}
@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+)
+
+// versions holds the versionGuard of each event type published with
+// PublishIfVersion.
+var versions = sync.Map{}
+
+// versionGuard holds the version of an event type. The mutex is held while
+// publishing so concurrent publishes expecting the same version can't both
+// succeed.
+type versionGuard struct {
+	mu      sync.Mutex
+	version uint64
+}
+
+func versionGuardOf(eventType reflect.Type) *versionGuard {
+	if guard, ok := versions.Load(eventType); ok {
+		return guard.(*versionGuard)
+	}
+	guard, _ := versions.LoadOrStore(eventType, &versionGuard{})
+	return guard.(*versionGuard)
+}
+
+// PublishIfVersion publishes an event like Publish only if the version of the
+// event type equals expected, giving compare-and-swap semantics to a sequence of
+// events, such as the transitions of a state machine, so concurrent publishers
+// don't lose updates. The version of every type starts at zero and is
+// incremented each time PublishIfVersion publishes an event of the type without
+// error, events published by other means don't change it. PublishIfVersion
+// returns false if the version didn't match and the event wasn't published, or if
+// publishing returned an error, which is returned as well and leaves the version
+// unchanged. Publishes of the same type with PublishIfVersion are serialized, so
+// handlers of T must not publish events of type T with PublishIfVersion.
+func PublishIfVersion[T any](event T, expected uint64) (bool, error) {
+	eventType := reflect.TypeOf(event)
+	if eventType == nil {
+		return false, nilEventError[T]()
+	}
+
+	guard := versionGuardOf(eventType)
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	if guard.version != expected {
+		return false, nil
+	}
+	if err := Publish(event); err != nil {
+		return false, err
+	}
+	guard.version++
+	return true, nil
+}
+
+// Version returns the version of events of type T, which is the number of events
+// of the type published with PublishIfVersion.
+func Version[T any]() uint64 {
+	v, ok := versions.Load(reflect.TypeOf(*new(T)))
+	if !ok {
+		return 0
+	}
+	guard := v.(*versionGuard)
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	return guard.version
+}
@@ -0,0 +1,60 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishIfVersion(t *testing.T) {
+	reset()
+
+	var received []int
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(event progressEvent) {
+		received = append(received, event.Count)
+	}))
+
+	ok, err := PublishIfVersion(progressEvent{Count: 1}, 0)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), Version[progressEvent]())
+
+	// A stale version is rejected without publishing
+	ok, err = PublishIfVersion(progressEvent{Count: 2}, 0)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), Version[progressEvent]())
+
+	ok, err = PublishIfVersion(progressEvent{Count: 3}, 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), Version[progressEvent]())
+	assert.Equal(t, []int{1, 3}, received)
+
+	// Failed publishes leave the version unchanged
+	ok, err = PublishIfVersion(orderEvent{Seq: 1}, 0)
+	assert.Error(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), Version[orderEvent]())
+}
+
+func TestPublishIfVersion_Concurrent(t *testing.T) {
+	reset()
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	var wg sync.WaitGroup
+	var published atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := PublishIfVersion(progressEvent{Count: 1}, 0); ok {
+				published.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), published.Load())
+}
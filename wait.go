@@ -0,0 +1,94 @@
+package eventbus
+
+import (
+	"context"
+)
+
+// WaitFor blocks until the next event of the given type is published and returns
+// it. If the context is done before an event is published the zero value and the
+// context's error are returned.
+func WaitFor[T any](ctx context.Context) (T, error) {
+	events, err := WaitForN[T](ctx, 1)
+	if len(events) == 0 {
+		var zero T
+		return zero, err
+	}
+	return events[0], err
+}
+
+// WaitForN blocks until the next n events of the given type are published and
+// returns them in the order they were received. The handler used to collect the
+// events is subscribed when WaitForN is called and unsubscribed before it
+// returns, so events published before the call are not observed. If the context
+// is done before n events are published the events collected so far are
+// returned along with the context's error.
+func WaitForN[T any](ctx context.Context, n int) ([]T, error) {
+	return waitFor[T](ctx, n, nil)
+}
+
+// WaitForMatch blocks until the next event of the given type satisfying pred is
+// published and returns it, discarding events that don't. The predicate is
+// invoked on the publishing goroutine. Like WaitFor, the handler used to observe
+// the events is unsubscribed before WaitForMatch returns, and if the context is
+// done before a matching event is published the zero value and the context's
+// error are returned.
+//
+//	status, err := eventbus.WaitForMatch(ctx, func(status JobStatus) bool {
+//		return status.Done
+//	})
+func WaitForMatch[T any](ctx context.Context, pred func(event T) bool) (T, error) {
+	events, err := waitFor(ctx, 1, pred)
+	if len(events) == 0 {
+		var zero T
+		return zero, err
+	}
+	return events[0], err
+}
+
+// waitFor collects the next n events satisfying pred, or every event if pred is
+// nil.
+func waitFor[T any](ctx context.Context, n int, pred func(event T) bool) ([]T, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	// The channel holds every event that will be collected, so the handler never
+	// blocks publishing. Events beyond the first n are dropped.
+	events := make(chan T, n)
+	id := Subscribe[T](HandlerFunc[T](func(event T) {
+		if pred != nil && !pred(event) {
+			return
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	}))
+	defer Unsubscribe[T](id)
+
+	collected := make([]T, 0, n)
+	for len(collected) < n {
+		select {
+		case <-ctx.Done():
+			return collected, ctx.Err()
+		case event := <-events:
+			collected = append(collected, event)
+		}
+	}
+	return collected, nil
+}
+
+// Serve subscribes the handler and blocks until the context is done, then
+// unsubscribes the handler and returns the context's error, mirroring the serve
+// loops of servers for simple consumer programs.
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	eventbus.Serve[UserCreated](ctx, handler)
+func Serve[T any](ctx context.Context, handler Handler[T]) error {
+	id := Subscribe(handler)
+	defer Unsubscribe[T](id)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
@@ -0,0 +1,146 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForN(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Keep a handler subscribed so publishing doesn't fail once WaitForN returns
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Eventually(t, func() bool {
+			return len(Subscriptions[progressEvent]()) == 2
+		}, time.Second, time.Millisecond)
+		for i := 1; i <= 5; i++ {
+			assert.NoError(t, Publish(progressEvent{Count: i}))
+		}
+	}()
+
+	events, err := WaitForN[progressEvent](ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []progressEvent{{Count: 1}, {Count: 2}, {Count: 3}}, events)
+	assert.Len(t, Subscriptions[progressEvent](), 1)
+	<-done
+}
+
+func TestWaitForN_Timeout(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Eventually(t, func() bool {
+			return len(Subscriptions[progressEvent]()) == 1
+		}, time.Second, time.Millisecond)
+		assert.NoError(t, Publish(progressEvent{Count: 1}))
+	}()
+
+	events, err := WaitForN[progressEvent](ctx, 3)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []progressEvent{{Count: 1}}, events)
+	assert.Empty(t, Subscriptions[progressEvent]())
+	<-done
+}
+
+func TestWaitFor(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Eventually(t, func() bool {
+			return len(Subscriptions[progressEvent]()) == 1
+		}, time.Second, time.Millisecond)
+		assert.NoError(t, Publish(progressEvent{Count: 7}))
+	}()
+
+	event, err := WaitFor[progressEvent](ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, progressEvent{Count: 7}, event)
+	<-done
+
+	event, err = WaitFor[progressEvent](ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, event)
+}
+
+func TestWaitForMatch(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	Subscribe[progressEvent](HandlerFunc[progressEvent](func(progressEvent) {}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Eventually(t, func() bool {
+			return len(Subscriptions[progressEvent]()) == 2
+		}, time.Second, time.Millisecond)
+		for i := 1; i <= 5; i++ {
+			assert.NoError(t, Publish(progressEvent{Count: i}))
+		}
+	}()
+
+	event, err := WaitForMatch(ctx, func(event progressEvent) bool {
+		return event.Count == 4
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, progressEvent{Count: 4}, event)
+	<-done
+	assert.Len(t, Subscriptions[progressEvent](), 1)
+}
+
+func TestWaitForMatch_Timeout(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	event, err := WaitForMatch(ctx, func(event progressEvent) bool {
+		return false
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, event)
+	assert.Empty(t, Subscriptions[progressEvent]())
+}
+
+func TestServe(t *testing.T) {
+	reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan progressEvent, 2)
+	served := make(chan error)
+	go func() {
+		served <- Serve[progressEvent](ctx, HandlerFunc[progressEvent](func(event progressEvent) {
+			received <- event
+		}))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(Subscriptions[progressEvent]()) == 1
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, Publish(progressEvent{Count: 1}))
+	assert.NoError(t, Publish(progressEvent{Count: 2}))
+	assert.Equal(t, progressEvent{Count: 1}, <-received)
+	assert.Equal(t, progressEvent{Count: 2}, <-received)
+
+	cancel()
+	assert.ErrorIs(t, <-served, context.Canceled)
+	assert.Empty(t, Subscriptions[progressEvent]())
+}
@@ -0,0 +1,33 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrZeroValue is reported through the error callback when an event is skipped
+// because it is the zero value of its type and WithSkipZeroValue is enabled.
+var ErrZeroValue = errors.New("eventbus: event is the zero value of its type")
+
+// skipZeroValue reports whether the event should be skipped because it is the
+// zero value of its type and skipping zero values is enabled. Skipped events are
+// reported to the error callback with the read lock released. The caller must
+// hold the read lock.
+func skipZeroValue[T any](event T) bool {
+	if !cfg.skipZeroValue {
+		return false
+	}
+
+	v := reflect.ValueOf(event)
+	if !v.IsValid() || !v.IsZero() {
+		return false
+	}
+	if callback := cfg.errorCallback; callback != nil {
+		err := fmt.Errorf("%w: %s", ErrZeroValue, typeName(reflect.TypeOf(event)))
+		unlocked(func() {
+			callback(err)
+		})
+	}
+	return true
+}
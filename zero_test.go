@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSkipZeroValue(t *testing.T) {
+	reset()
+	var errs []error
+	Configure(WithSkipZeroValue(), WithErrorCallback(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	event := userCreatedEvent{Name: "John Doe", Email: "jdoe@gmail.com"}
+	h := new(userCreatedHandler)
+	h.On("OnEvent", event).Return()
+	Subscribe[userCreatedEvent](h)
+
+	assert.NoError(t, Publish(userCreatedEvent{}))
+	assert.NoError(t, PublishAsync(userCreatedEvent{}))
+	h.AssertNumberOfCalls(t, "OnEvent", 0)
+	assert.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrZeroValue)
+
+	assert.NoError(t, Publish(event))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestWithSkipZeroValue_Disabled(t *testing.T) {
+	reset()
+	h := new(userCreatedHandler)
+	h.On("OnEvent", userCreatedEvent{}).Return()
+	Subscribe[userCreatedEvent](h)
+
+	assert.NoError(t, Publish(userCreatedEvent{}))
+	h.AssertNumberOfCalls(t, "OnEvent", 1)
+}
+
+func TestWithSkipZeroValue_SubscribeFromCallback(t *testing.T) {
+	reset()
+	Configure(WithSkipZeroValue(), WithErrorCallback(func(err error) {
+		Subscribe[orderEvent](HandlerFunc[orderEvent](func(orderEvent) {}))
+	}))
+
+	published := make(chan error, 1)
+	go func() {
+		published <- Publish(userCreatedEvent{})
+	}()
+	select {
+	case err := <-published:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish deadlocked subscribing from the error callback")
+	}
+	assert.NoError(t, Publish(orderEvent{Seq: 1}))
+}